@@ -0,0 +1,416 @@
+// Command versionctl exposes the version package to shell scripts and Makefiles.
+//
+// Usage:
+//
+//	versionctl parse [-format text|json] <version>
+//	versionctl validate <version>
+//	versionctl compare <a> <b>
+//	versionctl bump [-suffix s] <major|minor|patch> <version>
+//	versionctl satisfies <version> <range>
+//	versionctl sort [-reverse] < versions.txt
+//	versionctl banner [-ascii] [-font name] [-width n] [-border] [-border-style name] [-overflow policy] [-template name|file] [-format name] <app> <version>
+//
+// Exit codes: 0 on success, 1 when a check fails (invalid version, unsatisfied range)
+// and 2 on usage or input errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cjlapao/common-go-version/version"
+)
+
+const (
+	exitOK    = 0
+	exitFalse = 1
+	exitUsage = 2
+)
+
+// command is a versionctl subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(env *environment, args []string) int
+}
+
+// environment holds the streams a subcommand reads from and writes to
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands = []command{
+	{name: "parse", summary: "print the fields of a version", run: runParse},
+	{name: "validate", summary: "exit with 0 if the version is valid, 1 otherwise", run: runValidate},
+	{name: "compare", summary: "print -1, 0 or 1 comparing two versions", run: runCompare},
+	{name: "bump", summary: "increment the major, minor or patch part", run: runBump},
+	{name: "satisfies", summary: "exit with 0 if the version matches the range, 1 otherwise", run: runSatisfies},
+	{name: "sort", summary: "sort versions read from stdin, one per line", run: runSort},
+	{name: "banner", summary: "render the version banner", run: runBanner},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes versionctl with the given arguments and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	env := &environment{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		usage(stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(env, args[1:])
+		}
+	}
+
+	fmt.Fprintf(stderr, "versionctl: unknown command %q\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: versionctl <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead of exiting
+func newFlagSet(env *environment, name, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet("versionctl "+name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.stderr, "Usage: versionctl %s [flags] %s\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses flags and checks the number of positional arguments
+func parseArgs(fs *flag.FlagSet, args []string, want int) bool {
+	if err := fs.Parse(args); err != nil {
+		return false
+	}
+	if fs.NArg() != want {
+		fs.Usage()
+		return false
+	}
+	return true
+}
+
+// fail prints an error and returns the usage exit code
+func fail(env *environment, err error) int {
+	fmt.Fprintf(env.stderr, "versionctl: %v\n", err)
+	return exitUsage
+}
+
+// parsedVersion is the JSON representation printed by the parse command
+type parsedVersion struct {
+	Version    string `json:"version"`
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	Hash       string `json:"hash,omitempty"`
+	Suffix     string `json:"suffix,omitempty"`
+	Release    bool   `json:"release"`
+	PreRelease bool   `json:"prerelease"`
+}
+
+func runParse(env *environment, args []string) int {
+	fs := newFlagSet(env, "parse", "<version>")
+	format := fs.String("format", "text", "output format: text or json")
+	if !parseArgs(fs, args, 1) {
+		return exitUsage
+	}
+
+	info, err := version.Parse(fs.Arg(0))
+	if err != nil {
+		return fail(env, err)
+	}
+
+	parsed := parsedVersion{
+		Version:    info.String(),
+		Major:      info.Major,
+		Minor:      info.Minor,
+		Patch:      info.Patch,
+		Hash:       info.Hash,
+		Suffix:     info.Suffix,
+		Release:    info.IsRelease(),
+		PreRelease: info.IsPreRelease(),
+	}
+
+	switch *format {
+	case "text":
+		fmt.Fprintf(env.stdout, "version:    %s\n", parsed.Version)
+		fmt.Fprintf(env.stdout, "major:      %d\n", parsed.Major)
+		fmt.Fprintf(env.stdout, "minor:      %d\n", parsed.Minor)
+		fmt.Fprintf(env.stdout, "patch:      %d\n", parsed.Patch)
+		fmt.Fprintf(env.stdout, "hash:       %s\n", parsed.Hash)
+		fmt.Fprintf(env.stdout, "suffix:     %s\n", parsed.Suffix)
+		fmt.Fprintf(env.stdout, "release:    %t\n", parsed.Release)
+		fmt.Fprintf(env.stdout, "prerelease: %t\n", parsed.PreRelease)
+	case "json":
+		bytes, err := json.Marshal(parsed)
+		if err != nil {
+			return fail(env, err)
+		}
+		fmt.Fprintln(env.stdout, string(bytes))
+	default:
+		return fail(env, fmt.Errorf("invalid format: %s (expected: text or json)", *format))
+	}
+
+	return exitOK
+}
+
+func runValidate(env *environment, args []string) int {
+	fs := newFlagSet(env, "validate", "<version>")
+	if !parseArgs(fs, args, 1) {
+		return exitUsage
+	}
+
+	if _, err := version.Parse(fs.Arg(0)); err != nil {
+		fmt.Fprintf(env.stderr, "versionctl: %v\n", err)
+		return exitFalse
+	}
+	return exitOK
+}
+
+func runCompare(env *environment, args []string) int {
+	fs := newFlagSet(env, "compare", "<a> <b>")
+	if !parseArgs(fs, args, 2) {
+		return exitUsage
+	}
+
+	a, err := version.Parse(fs.Arg(0))
+	if err != nil {
+		return fail(env, err)
+	}
+	b, err := version.Parse(fs.Arg(1))
+	if err != nil {
+		return fail(env, err)
+	}
+
+	fmt.Fprintln(env.stdout, version.Compare(a, b))
+	return exitOK
+}
+
+func runBump(env *environment, args []string) int {
+	fs := newFlagSet(env, "bump", "<major|minor|patch> <version>")
+	suffix := fs.String("suffix", "", "suffix to add to the bumped version (alpha, beta, dev, rcN or canary)")
+	if !parseArgs(fs, args, 2) {
+		return exitUsage
+	}
+
+	info, err := version.Parse(fs.Arg(1))
+	if err != nil {
+		return fail(env, err)
+	}
+
+	next, err := info.Bump(version.Part(fs.Arg(0)))
+	if err != nil {
+		return fail(env, err)
+	}
+
+	if *suffix != "" {
+		next, err = version.Parse(next.String() + "-" + *suffix)
+		if err != nil {
+			return fail(env, err)
+		}
+	}
+
+	fmt.Fprintln(env.stdout, next.String())
+	return exitOK
+}
+
+func runSatisfies(env *environment, args []string) int {
+	fs := newFlagSet(env, "satisfies", "<version> <range>")
+	if !parseArgs(fs, args, 2) {
+		return exitUsage
+	}
+
+	info, err := version.Parse(fs.Arg(0))
+	if err != nil {
+		return fail(env, err)
+	}
+
+	ok, err := info.Satisfies(fs.Arg(1))
+	if err != nil {
+		return fail(env, err)
+	}
+	if !ok {
+		return exitFalse
+	}
+	return exitOK
+}
+
+func runSort(env *environment, args []string) int {
+	fs := newFlagSet(env, "sort", "")
+	reverse := fs.Bool("reverse", false, "sort in descending order")
+	if !parseArgs(fs, args, 0) {
+		return exitUsage
+	}
+
+	var versions []*version.Info
+	scanner := bufio.NewScanner(env.stdin)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		info, err := version.Parse(text)
+		if err != nil {
+			return fail(env, fmt.Errorf("line %d: %w", line, err))
+		}
+		versions = append(versions, info)
+	}
+	if err := scanner.Err(); err != nil {
+		return fail(env, err)
+	}
+
+	version.Sort(versions)
+
+	for idx := range versions {
+		info := versions[idx]
+		if *reverse {
+			info = versions[len(versions)-1-idx]
+		}
+		fmt.Fprintln(env.stdout, info.String())
+	}
+	return exitOK
+}
+
+func runBanner(env *environment, args []string) int {
+	fs := newFlagSet(env, "banner", "<app> <version>")
+	ascii := fs.Bool("ascii", false, "render the app name as ASCII art")
+	font := fs.String("font", string(version.FontStyleSlant), "ASCII art font style")
+	width := fs.Int("width", 0, "fixed banner width (0 sizes the banner to its content)")
+	border := fs.Bool("border", false, "draw a border around the banner (defaults to a border with -width only)")
+	borderStyle := fs.String("border-style", "asterisk", "border characters, implies -border: asterisk, ascii, single, double, rounded, heavy or none")
+	overflow := fs.String("overflow", string(version.OverflowClip), "long metadata lines: clip, ellipsis, wrap or word-wrap")
+	layout := fs.String("template", "", "banner template: classic, compact, two-column or a template file")
+	format := fs.String("format", "text", "output format: text, markdown, markdown-table, html or svg")
+	author := fs.String("author", "", "author metadata line")
+	company := fs.String("company", "", "company metadata line")
+	copyright := fs.String("copyright", "", "copyright metadata line")
+	repo := fs.String("repo", "", "repository metadata line")
+	if !parseArgs(fs, args, 2) {
+		return exitUsage
+	}
+	if *width < 0 {
+		return fail(env, errors.New("width cannot be negative"))
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["border-style"] && set["border"] && !*border {
+		return fail(env, errors.New("-border-style cannot be combined with -border=false"))
+	}
+	style, err := version.BorderStyleByName(*borderStyle)
+	if err != nil {
+		return fail(env, err)
+	}
+	overflowPolicy, err := version.ParseOverflow(*overflow)
+	if err != nil {
+		return fail(env, err)
+	}
+	renderer, err := version.RendererByName(*format)
+	if err != nil {
+		return fail(env, err)
+	}
+	if *layout != "" && *format != "text" {
+		return fail(env, errors.New("-template and -format cannot be combined"))
+	}
+
+	info, err := version.Parse(fs.Arg(1))
+	if err != nil {
+		return fail(env, err)
+	}
+	info.Author = *author
+	info.Company = *company
+	info.Copyright = *copyright
+	info.Repo = *repo
+
+	opts := version.BannerOptions{
+		UseASCII:   *ascii,
+		AutoWidth:  *width == 0,
+		FixedWidth: *width,
+		FontStyle:  version.FontStyle(*font),
+		Overflow:   overflowPolicy,
+	}
+	if set["border-style"] {
+		*border = true
+		opts.Border = &style
+	}
+	if set["border"] || set["border-style"] {
+		opts.ShowBorder = border
+	}
+
+	var banner string
+	if *layout != "" {
+		banner, err = renderTemplate(*layout, fs.Arg(0), info, opts)
+	} else {
+		banner, err = renderBanner(fs.Arg(0), info, opts, renderer)
+	}
+	if err != nil {
+		return fail(env, err)
+	}
+
+	fmt.Fprintln(env.stdout, banner)
+	return exitOK
+}
+
+// renderTemplate renders the banner with a built-in template or a template file, turning go-figure
+// panics into errors like renderBanner
+func renderTemplate(layout, appName string, info *version.Info, opts version.BannerOptions) (banner string, err error) {
+	defer recoverFontPanic(opts.FontStyle, &err)
+
+	tmpl, err := version.TemplateByName(layout)
+	if err != nil {
+		data, readErr := os.ReadFile(layout)
+		if readErr != nil {
+			return "", err
+		}
+		tmpl = string(data)
+	}
+
+	banner, err = version.BannerFromTemplateWithOptions(tmpl, appName, info, opts)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", layout, err)
+	}
+	return banner, nil
+}
+
+// renderBanner renders the banner, turning the panics go-figure raises for unknown fonts
+// or unsupported characters into errors
+func renderBanner(appName string, info *version.Info, opts version.BannerOptions, renderer version.BannerRenderer) (banner string, err error) {
+	defer recoverFontPanic(opts.FontStyle, &err)
+
+	return version.RenderBanner(appName, info, opts, renderer), nil
+}
+
+// recoverFontPanic turns a go-figure panic for an unknown font or unsupported characters into *err
+func recoverFontPanic(font version.FontStyle, err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("cannot render banner with font %q: %v", font, r)
+	}
+}
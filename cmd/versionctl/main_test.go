@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "no arguments",
+			args:       nil,
+			wantCode:   exitUsage,
+			wantStderr: "Usage: versionctl",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"frobnicate"},
+			wantCode:   exitUsage,
+			wantStderr: `unknown command "frobnicate"`,
+		},
+		{
+			name:       "help",
+			args:       []string{"--help"},
+			wantCode:   exitOK,
+			wantStdout: "satisfies",
+		},
+		{
+			name:       "parse text",
+			args:       []string{"parse", "1.2.3:abc123-beta"},
+			wantCode:   exitOK,
+			wantStdout: "version:    1.2.3:abc123-beta\nmajor:      1\nminor:      2\npatch:      3\nhash:       ABC123\nsuffix:     beta\nrelease:    false\nprerelease: true\n",
+		},
+		{
+			name:       "parse json",
+			args:       []string{"parse", "-format", "json", "v2.0.0"},
+			wantCode:   exitOK,
+			wantStdout: `{"version":"2.0.0","major":2,"minor":0,"patch":0,"release":true,"prerelease":false}` + "\n",
+		},
+		{
+			name:       "parse invalid format flag",
+			args:       []string{"parse", "-format", "yaml", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid format: yaml",
+		},
+		{
+			name:       "parse invalid version",
+			args:       []string{"parse", "1.2"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid version format",
+		},
+		{
+			name:     "validate valid",
+			args:     []string{"validate", "0.0.1-canary"},
+			wantCode: exitOK,
+		},
+		{
+			name:       "validate invalid",
+			args:       []string{"validate", ":abc123"},
+			wantCode:   exitFalse,
+			wantStderr: "invalid version format",
+		},
+		{
+			name:       "validate missing argument",
+			args:       []string{"validate"},
+			wantCode:   exitUsage,
+			wantStderr: "Usage: versionctl validate",
+		},
+		{
+			name:       "compare lower",
+			args:       []string{"compare", "1.0.0-beta", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "-1\n",
+		},
+		{
+			name:       "compare equal ignores hash",
+			args:       []string{"compare", "1.0.0:AAA", "1.0.0:BBB"},
+			wantCode:   exitOK,
+			wantStdout: "0\n",
+		},
+		{
+			name:       "compare greater",
+			args:       []string{"compare", "1.10.0", "1.9.0"},
+			wantCode:   exitOK,
+			wantStdout: "1\n",
+		},
+		{
+			name:       "bump minor",
+			args:       []string{"bump", "minor", "1.2.3:ABC-dev"},
+			wantCode:   exitOK,
+			wantStdout: "1.3.0\n",
+		},
+		{
+			name:       "bump with suffix",
+			args:       []string{"bump", "-suffix", "rc1", "major", "1.2.3"},
+			wantCode:   exitOK,
+			wantStdout: "2.0.0-rc1\n",
+		},
+		{
+			name:       "bump invalid part",
+			args:       []string{"bump", "build", "1.2.3"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid version part: build",
+		},
+		{
+			name:     "satisfies match",
+			args:     []string{"satisfies", "1.4.0", "^1.2.0"},
+			wantCode: exitOK,
+		},
+		{
+			name:     "satisfies no match",
+			args:     []string{"satisfies", "2.0.0", ">=1.2.0, <2.0.0"},
+			wantCode: exitFalse,
+		},
+		{
+			name:       "satisfies invalid range",
+			args:       []string{"satisfies", "2.0.0", ">="},
+			wantCode:   exitUsage,
+			wantStderr: "invalid constraint",
+		},
+		{
+			name:       "sort",
+			args:       []string{"sort"},
+			stdin:      "1.0.0\n0.9.0\n\n1.0.0-rc1\n0.10.0\n",
+			wantCode:   exitOK,
+			wantStdout: "0.9.0\n0.10.0\n1.0.0-rc1\n1.0.0\n",
+		},
+		{
+			name:       "sort reverse",
+			args:       []string{"sort", "-reverse"},
+			stdin:      "1.0.0\n1.0.0-beta\n2.0.0\n",
+			wantCode:   exitOK,
+			wantStdout: "2.0.0\n1.0.0\n1.0.0-beta\n",
+		},
+		{
+			name:       "sort invalid line",
+			args:       []string{"sort"},
+			stdin:      "1.0.0\nnope\n",
+			wantCode:   exitUsage,
+			wantStderr: "line 2: invalid version format",
+		},
+		{
+			name:       "banner simple",
+			args:       []string{"banner", "-author", "Jane", "MyApp", "1.2.3-beta"},
+			wantCode:   exitOK,
+			wantStdout: "MyApp\n\nv1.2.3 [BETA]\n\nAuthor:    Jane\n\n",
+		},
+		{
+			name:       "banner with border",
+			args:       []string{"banner", "-border", "-width", "20", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "********************\n*                  *\n*       App        *\n*                  *\n*      v1.0.0      *\n*                  *\n********************\n",
+		},
+		{
+			name:       "banner with rounded border",
+			args:       []string{"banner", "-border", "-border-style", "rounded", "-width", "12", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "╭──────────╮\n│          │\n│   App    │\n│          │\n│  v1.0.0  │\n│          │\n╰──────────╯\n",
+		},
+		{
+			name:       "banner fixed width defaults to a border",
+			args:       []string{"banner", "-width", "12", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "************\n*          *\n*   App    *\n",
+		},
+		{
+			name:       "banner fixed width without border",
+			args:       []string{"banner", "-border=false", "-width", "12", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "App\n\nv1.0.0\n",
+		},
+		{
+			name:       "banner border style implies border",
+			args:       []string{"banner", "-border-style", "double", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "╔════════╗\n║        ║\n║  App   ║\n",
+		},
+		{
+			name:       "banner border style without border",
+			args:       []string{"banner", "-border=false", "-border-style", "double", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "-border-style cannot be combined with -border=false",
+		},
+		{
+			name:       "banner word wrap",
+			args:       []string{"banner", "-border", "-width", "30", "-overflow", "word-wrap", "-repo", "https://github.com/acme/tool", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "* Repo:      https://github. *\n*            com/acme/tool   *\n",
+		},
+		{
+			name:       "banner unknown overflow",
+			args:       []string{"banner", "-overflow", "scroll", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid overflow: scroll",
+		},
+		{
+			name:       "banner unknown border style",
+			args:       []string{"banner", "-border-style", "dotted", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid border style: dotted",
+		},
+		{
+			name:       "banner compact template",
+			args:       []string{"banner", "-template", "compact", "-author", "Jane", "App", "1.0.0-beta"},
+			wantCode:   exitOK,
+			wantStdout: "App v1.0.0 [BETA] | Author: Jane\n",
+		},
+		{
+			name:       "banner unknown template",
+			args:       []string{"banner", "-template", "fancy", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid banner template: fancy",
+		},
+		{
+			name:       "banner markdown table",
+			args:       []string{"banner", "-format", "markdown-table", "-author", "Jane", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: "| Field | Value |\n| --- | --- |\n| Name | App |\n| Version | v1.0.0 |\n| Author | Jane |\n",
+		},
+		{
+			name:       "banner html",
+			args:       []string{"banner", "-format", "html", "App", "1.0.0"},
+			wantCode:   exitOK,
+			wantStdout: `<pre class="banner"><span class="banner-line banner-section-title"><span class="banner-role-title">App</span></span>`,
+		},
+		{
+			name:       "banner unknown format",
+			args:       []string{"banner", "-format", "pdf", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "invalid banner renderer: pdf",
+		},
+		{
+			name:       "banner template with format",
+			args:       []string{"banner", "-template", "compact", "-format", "html", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: "-template and -format cannot be combined",
+		},
+		{
+			name:       "banner unknown font",
+			args:       []string{"banner", "-ascii", "-font", "no-such-font", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: `cannot render banner with font "no-such-font"`,
+		},
+		{
+			name:       "banner template unknown font",
+			args:       []string{"banner", "-template", "classic", "-ascii", "-font", "no-such-font", "App", "1.0.0"},
+			wantCode:   exitUsage,
+			wantStderr: `cannot render banner with font "no-such-font"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantStdout != "" && !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if tt.wantStdout == "" && tt.wantCode != exitOK && stdout.Len() > 0 {
+				t.Errorf("stdout = %q, want empty output on failure", stdout.String())
+			}
+			if tt.wantStderr != "" && !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestBannerTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banner.tmpl")
+	if err := os.WriteFile(path, []byte("== {{.AppName}} ==\n{{.Version}} {{.Nope}}"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"banner", "-template", path, "App", "1.0.0"}, strings.NewReader(""), &stdout, &stderr)
+	if code != exitUsage {
+		t.Errorf("exit code = %d, want %d", code, exitUsage)
+	}
+	if want := "banner template line 2, column 15"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want it to contain %q", stderr.String(), want)
+	}
+}
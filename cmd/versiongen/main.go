@@ -0,0 +1,250 @@
+// Command versiongen generates a Go file declaring a ready-made *version.Info and banner settings,
+// so builds no longer depend on -ldflags "-X ..." to embed version information.
+//
+// Add a directive next to your main package and run go generate:
+//
+//	//go:generate go run github.com/cjlapao/common-go-version/cmd/versiongen -git
+//
+// The version is read from the .VERSION file (X.Y.Z), the commit hash is optionally taken from git and
+// author, company, copyright, repo and banner settings come from an optional versiongen.json file:
+//
+//	{
+//	  "app_name": "MyApp",
+//	  "suffix": "beta",
+//	  "author": "Jane Doe",
+//	  "company": "Acme Corp",
+//	  "copyright": "2025 Acme Corp",
+//	  "repo": "https://github.com/acme/myapp",
+//	  "banner": {"ascii": true, "font": "big", "width": 0, "border": false}
+//	}
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/cjlapao/common-go-version/version"
+)
+
+// config is the optional versiongen.json file
+type config struct {
+	AppName   string       `json:"app_name"`
+	Suffix    string       `json:"suffix"`
+	Author    string       `json:"author"`
+	Company   string       `json:"company"`
+	Copyright string       `json:"copyright"`
+	Repo      string       `json:"repo"`
+	Banner    bannerConfig `json:"banner"`
+}
+
+// bannerConfig holds the banner settings of the config file
+type bannerConfig struct {
+	ASCII  bool   `json:"ascii"`
+	Font   string `json:"font"`
+	Width  int    `json:"width"`
+	Border *bool  `json:"border"`
+}
+
+// options are the resolved command-line options
+type options struct {
+	VersionFile string
+	ConfigFile  string
+	Output      string
+	Package     string
+	Suffix      string
+	UseGit      bool
+}
+
+// gitRevision returns the short commit hash of the repository containing dir
+var gitRevision = func(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot read git revision: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run executes versiongen with the given arguments and returns the process exit code
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("versiongen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	opts := options{}
+	fs.StringVar(&opts.VersionFile, "version-file", ".VERSION", "file containing the X.Y.Z version")
+	fs.StringVar(&opts.ConfigFile, "config", "versiongen.json", "optional JSON file with metadata and banner settings")
+	fs.StringVar(&opts.Output, "output", "version_gen.go", "generated file")
+	fs.StringVar(&opts.Package, "package", os.Getenv("GOPACKAGE"), "package of the generated file (defaults to $GOPACKAGE or main)")
+	fs.StringVar(&opts.Suffix, "suffix", "", "build suffix (alpha, beta, dev, rcN or canary), overrides the config file")
+	fs.BoolVar(&opts.UseGit, "git", false, "add the short git commit hash to the version")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if opts.Package == "" {
+		opts.Package = "main"
+	}
+
+	src, err := generate(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "versiongen: %v\n", err)
+		return 1
+	}
+
+	// The generated file is ordinary source code checked into the repository, so it gets the usual 0644 of source files
+	if err := os.WriteFile(opts.Output, src, 0o644); err != nil {
+		fmt.Fprintf(stderr, "versiongen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generate builds the formatted source of the generated file
+func generate(opts options) ([]byte, error) {
+	raw, err := os.ReadFile(opts.VersionFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read version file: %w", err)
+	}
+
+	cfg, err := loadConfig(opts.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Suffix != "" {
+		cfg.Suffix = opts.Suffix
+	}
+	if cfg.Banner.Font == "" {
+		cfg.Banner.Font = string(version.FontStyleSlant)
+	}
+
+	base, err := version.Parse(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.VersionFile, err)
+	}
+
+	// The git revision and the configured suffix replace the ones already in the version file instead of being appended
+	hash, suffix := base.Hash, base.Suffix
+	if opts.UseGit {
+		if hash, err = gitRevision(filepath.Dir(opts.VersionFile)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Suffix != "" {
+		suffix = cfg.Suffix
+	}
+	versionStr := fmt.Sprintf("%d.%d.%d", base.Major, base.Minor, base.Patch)
+	if hash != "" {
+		versionStr += ":" + hash
+	}
+	if suffix != "" {
+		versionStr += "-" + suffix
+	}
+
+	info, err := version.Parse(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.VersionFile, err)
+	}
+	info.Author = cfg.Author
+	info.Company = cfg.Company
+	info.Copyright = cfg.Copyright
+	info.Repo = cfg.Repo
+
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, templateData{
+		Source:  filepath.Base(opts.VersionFile),
+		Package: opts.Package,
+		AppName: cfg.AppName,
+		Info:    info,
+		Banner:  cfg.Banner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated source: %w", err)
+	}
+	return src, nil
+}
+
+// loadConfig reads the config file, a missing file yields an empty config
+func loadConfig(path string) (config, error) {
+	var cfg config
+	if path == "" {
+		return cfg, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// templateData is passed to fileTemplate
+type templateData struct {
+	Source  string
+	Package string
+	AppName string
+	Info    *version.Info
+	Banner  bannerConfig
+}
+
+var fileTemplate = template.Must(template.New("version_gen.go").Funcs(template.FuncMap{
+	"quote": func(s string) string { return fmt.Sprintf("%q", s) },
+}).Parse(`// Code generated by versiongen from {{ .Source }}; DO NOT EDIT.
+
+package {{ .Package }}
+
+import "github.com/cjlapao/common-go-version/version"
+
+// BannerAppName is the application name shown in the banner
+const BannerAppName = {{ quote .AppName }}
+
+// VersionInfo is the version information of this build
+var VersionInfo = &version.Info{
+	Version:   {{ quote .Info.Version }},
+	Major:     {{ .Info.Major }},
+	Minor:     {{ .Info.Minor }},
+	Patch:     {{ .Info.Patch }},
+	Hash:      {{ quote .Info.Hash }},
+	Suffix:    {{ quote .Info.Suffix }},
+	Author:    {{ quote .Info.Author }},
+	Company:   {{ quote .Info.Company }},
+	Copyright: {{ quote .Info.Copyright }},
+	Repo:      {{ quote .Info.Repo }},
+}
+{{ if .Banner.Border }}
+var bannerShowBorder = {{ .Banner.Border }}
+{{ end }}
+// BannerOptions are the banner settings of this build
+var BannerOptions = version.BannerOptions{
+	UseASCII:   {{ .Banner.ASCII }},
+	AutoWidth:  {{ eq .Banner.Width 0 }},
+	FixedWidth: {{ .Banner.Width }},
+	FontStyle:  version.FontStyle({{ quote .Banner.Font }}),
+{{- if .Banner.Border }}
+	ShowBorder: &bannerShowBorder,
+{{- end }}
+}
+`))
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates the given files in a temporary directory and returns its path
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	original := gitRevision
+	defer func() { gitRevision = original }()
+	gitRevision = func(string) (string, error) { return "4f00abc", nil }
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		opts    options
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:  "version file only",
+			files: map[string]string{".VERSION": "0.1.2\n"},
+			want: []string{
+				"package main",
+				`Version:   "0.1.2",`,
+				"Minor:     1,",
+				"AutoWidth:  true,",
+				`FontStyle:  version.FontStyle("slant"),`,
+			},
+			notWant: []string{"ShowBorder"},
+		},
+		{
+			name: "config and git",
+			files: map[string]string{
+				".VERSION":        "1.4.0",
+				"versiongen.json": `{"app_name":"Demo","suffix":"beta","author":"Jane \"JD\" Doe","repo":"https://example.com/demo","banner":{"ascii":true,"font":"big","width":72,"border":true}}`,
+			},
+			opts: options{Package: "demo", UseGit: true},
+			want: []string{
+				"package demo",
+				`const BannerAppName = "Demo"`,
+				`Version:   "1.4.0:4f00abc-beta",`,
+				`Hash:      "4F00ABC",`,
+				`Author:    "Jane \"JD\" Doe",`,
+				"var bannerShowBorder = true",
+				"FixedWidth: 72,",
+				"AutoWidth:  false,",
+				`FontStyle:  version.FontStyle("big"),`,
+				"ShowBorder: &bannerShowBorder,",
+			},
+		},
+		{
+			name: "suffix flag overrides config",
+			files: map[string]string{
+				".VERSION":        "2.0.0",
+				"versiongen.json": `{"suffix":"beta"}`,
+			},
+			opts: options{Suffix: "rc1"},
+			want: []string{`Version:   "2.0.0-rc1",`},
+		},
+		{
+			name: "suffix in version file with git",
+			files: map[string]string{
+				".VERSION":        "1.4.0-beta\n",
+				"versiongen.json": `{"suffix":"beta"}`,
+			},
+			opts:    options{UseGit: true},
+			want:    []string{`Version:   "1.4.0:4f00abc-beta",`, `Suffix:    "beta",`},
+			notWant: []string{"beta-beta", "beta:"},
+		},
+		{
+			name:  "suffix flag replaces the version file suffix",
+			files: map[string]string{".VERSION": "1.4.0:abc123-beta"},
+			opts:  options{Suffix: "rc2"},
+			want:  []string{`Version:   "1.4.0:ABC123-rc2",`, `Hash:      "ABC123",`},
+		},
+		{
+			name:    "invalid version file",
+			files:   map[string]string{".VERSION": "1.2"},
+			wantErr: "invalid version format",
+		},
+		{
+			name:    "invalid config",
+			files:   map[string]string{".VERSION": "1.2.0", "versiongen.json": "{"},
+			wantErr: "invalid config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			opts := tt.opts
+			opts.VersionFile = filepath.Join(dir, ".VERSION")
+			opts.ConfigFile = filepath.Join(dir, "versiongen.json")
+			if opts.Package == "" {
+				opts.Package = "main"
+			}
+
+			src, err := generate(opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("generate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generate() unexpected error: %v", err)
+			}
+
+			if _, err := parser.ParseFile(token.NewFileSet(), "version_gen.go", src, 0); err != nil {
+				t.Fatalf("generated source does not parse: %v\n%s", err, src)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(src), want) {
+					t.Errorf("generated source missing %q:\n%s", want, src)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(src), notWant) {
+					t.Errorf("generated source contains %q:\n%s", notWant, src)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateGitFailure(t *testing.T) {
+	original := gitRevision
+	defer func() { gitRevision = original }()
+	gitRevision = func(string) (string, error) { return "", errors.New("not a git repository") }
+
+	dir := writeFiles(t, map[string]string{".VERSION": "1.0.0"})
+	_, err := generate(options{VersionFile: filepath.Join(dir, ".VERSION"), Package: "main", UseGit: true})
+	if err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Fatalf("generate() error = %v, want git error", err)
+	}
+}
+
+func TestRunWritesOutput(t *testing.T) {
+	dir := writeFiles(t, map[string]string{".VERSION": "3.2.1"})
+	output := filepath.Join(dir, "version_gen.go")
+
+	var stderr bytes.Buffer
+	code := run([]string{
+		"-version-file", filepath.Join(dir, ".VERSION"),
+		"-config", filepath.Join(dir, "missing.json"),
+		"-output", output,
+		"-package", "app",
+	}, &stderr)
+	if code != 0 {
+		t.Fatalf("run() = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+
+	src, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.HasPrefix(string(src), "// Code generated by versiongen from .VERSION; DO NOT EDIT.") {
+		t.Errorf("generated file missing header:\n%s", src)
+	}
+	if !strings.Contains(string(src), `Version:   "3.2.1",`) {
+		t.Errorf("generated file missing version:\n%s", src)
+	}
+
+	if code := run([]string{"-version-file", filepath.Join(dir, "nope")}, &stderr); code != 1 {
+		t.Errorf("run() with missing version file = %d, want 1", code)
+	}
+}
@@ -0,0 +1,86 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alignment positions a banner section horizontally
+type Alignment string
+
+const (
+	// AlignLeft - flush with the left edge
+	AlignLeft Alignment = "left"
+	// AlignCenter - centered
+	AlignCenter Alignment = "center"
+	// AlignRight - flush with the right edge
+	AlignRight Alignment = "right"
+)
+
+// BannerMargin is the space around the whole banner
+type BannerMargin struct {
+	// Top and Bottom are the number of blank lines before and after the banner
+	Top    int
+	Bottom int
+	// Left is the number of spaces each line is indented by
+	Left int
+}
+
+// ParseAlignment parses an alignment: left, center or right
+func ParseAlignment(s string) (Alignment, error) {
+	switch align := Alignment(strings.ToLower(strings.TrimSpace(s))); align {
+	case AlignLeft, AlignCenter, AlignRight:
+		return align, nil
+	}
+	return "", fmt.Errorf("invalid alignment: %s (expected: left, center or right)", s)
+}
+
+// alignText positions text within width columns, left aligned text is not padded
+func alignText(text string, width int, align Alignment) string {
+	textWidth := ansiWidth(text)
+	if textWidth >= width {
+		return text
+	}
+
+	switch align {
+	case AlignCenter:
+		return centerText(text, width)
+	case AlignRight:
+		return strings.Repeat(" ", width-textWidth) + text
+	}
+	return text
+}
+
+// alignBlock positions lines as a block within width columns, keeping them left aligned with each other
+// so that columns inside the block, such as metadata values, stay lined up
+func alignBlock(lines []string, width int, align Alignment) []string {
+	if align != AlignCenter && align != AlignRight {
+		return lines
+	}
+
+	blockWidth := longestLineWidth(lines)
+	aligned := make([]string, len(lines))
+	for idx, line := range lines {
+		padded := line + strings.Repeat(" ", max(blockWidth-visibleWidth(line), 0))
+		aligned[idx] = strings.TrimRight(alignText(padded, width, align), " ")
+	}
+	return aligned
+}
+
+// apply indents the non-blank lines and surrounds them with blank lines
+func (m BannerMargin) apply(lines []BannerLine) []BannerLine {
+	result := make([]BannerLine, 0, len(lines)+max(m.Top, 0)+max(m.Bottom, 0))
+	for i := 0; i < m.Top; i++ {
+		result = append(result, BannerLine{Section: SectionBlank})
+	}
+	for _, line := range lines {
+		if m.Left > 0 && strings.TrimSpace(stripANSI(line.Text())) != "" {
+			line = line.prepend(spaces(m.Left))
+		}
+		result = append(result, line)
+	}
+	for i := 0; i < m.Bottom; i++ {
+		result = append(result, BannerLine{Section: SectionBlank})
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBannerAlignment(t *testing.T) {
+	info := &Info{Major: 1, Author: "Jane", Repo: "github.com/acme/tool"}
+	noPadding := 0
+
+	tests := []struct {
+		name string
+		opts BannerOptions
+		want []string
+	}{
+		{
+			name: "defaults",
+			opts: BannerOptions{FixedWidth: 36},
+			want: []string{
+				"*               App                *",
+				"*              v1.0.0              *",
+				"* Author:    Jane                  *",
+				"* Repo:      github.com/acme/tool  *",
+			},
+		},
+		{
+			name: "left title, right version and metadata",
+			opts: BannerOptions{FixedWidth: 36, TitleAlign: AlignLeft, VersionAlign: AlignRight, MetadataAlign: AlignRight},
+			want: []string{
+				"* App                              *",
+				"*                           v1.0.0 *",
+				"*  Author:    Jane                 *",
+				"*  Repo:      github.com/acme/tool *",
+			},
+		},
+		{
+			name: "centered metadata without padding",
+			opts: BannerOptions{FixedWidth: 40, MetadataAlign: AlignCenter, Padding: &noPadding},
+			want: []string{
+				"*                 App                  *",
+				"*                v1.0.0                *",
+				"*   Author:    Jane                    *",
+				"*   Repo:      github.com/acme/tool    *",
+			},
+		},
+		{
+			name: "border-less",
+			opts: BannerOptions{AutoWidth: true, TitleAlign: AlignCenter, VersionAlign: AlignRight},
+			want: []string{
+				"              App              ",
+				"                         v1.0.0",
+				"Author:    Jane",
+				"Repo:      github.com/acme/tool",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BannerWithOptions("App", info, tt.opts)
+			lines := strings.Split(got, "\n")
+			for _, want := range tt.want {
+				found := false
+				for _, line := range lines {
+					found = found || line == want
+				}
+				if !found {
+					t.Errorf("banner is missing line %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBannerPaddingAndMargin(t *testing.T) {
+	showBorder := true
+	padding := 3
+	got := BannerWithOptions("App", &Info{Major: 1, Author: "Jane"}, BannerOptions{
+		AutoWidth:  true,
+		ShowBorder: &showBorder,
+		Padding:    &padding,
+		Margin:     BannerMargin{Top: 2, Bottom: 1, Left: 4},
+	})
+
+	want := strings.Join([]string{
+		"",
+		"",
+		"    ***********************",
+		"    *                     *",
+		"    *         App         *",
+		"    *                     *",
+		"    *       v1.0.0        *",
+		"    *                     *",
+		"    *   Author:    Jane   *",
+		"    *                     *",
+		"    ***********************",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("BannerWithOptions() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBannerExplicitDefaultPadding(t *testing.T) {
+	info := &Info{Major: 1, Author: "Jane"}
+	padding := defaultPadding
+	showBorder := true
+	// "My App" fits on one line at these widths without the art margin but wraps with it
+	for _, opts := range []BannerOptions{
+		{UseASCII: true, FixedWidth: 54, FontStyle: FontStyleSlant},
+		{UseASCII: true, AutoWidth: true, ShowBorder: &showBorder, MaxWidth: 54, FontStyle: FontStyleSlant},
+	} {
+		want := BannerWithOptions("My App", info, opts)
+		opts.Padding = &padding
+		if got := BannerWithOptions("My App", info, opts); got != want {
+			t.Errorf("BannerWithOptions() with Padding 1 =\n%s\nwant the default\n%s", got, want)
+		}
+	}
+}
+
+func TestParseAlignment(t *testing.T) {
+	for _, name := range []string{"left", "Center", " right "} {
+		if _, err := ParseAlignment(name); err != nil {
+			t.Errorf("ParseAlignment(%q) unexpected error: %v", name, err)
+		}
+	}
+	if _, err := ParseAlignment("justify"); err == nil {
+		t.Errorf("ParseAlignment(\"justify\") expected an error")
+	}
+}
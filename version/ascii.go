@@ -407,7 +407,7 @@ func getMaxLineWidth(text string) int {
 	lines := strings.Split(text, "\n")
 	maxWidth := 0
 	for _, line := range lines {
-		lineLen := len(line)
+		lineLen := stringWidth(line)
 		if lineLen > maxWidth {
 			maxWidth = lineLen
 		}
@@ -2,14 +2,16 @@ package version
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
-	"unicode/utf8"
 )
 
 const (
 	defaultBoxWidth = 80
 	borderChar      = "*"
 	paddingLeft     = 2
+	defaultPadding  = 1
 )
 
 // BannerOptions configures banner generation behavior
@@ -24,6 +26,34 @@ type BannerOptions struct {
 	FontStyle FontStyle
 	// ShowBorder determines whether to show the *** border box (defaults to true for fixed width, false for auto-width)
 	ShowBorder *bool
+	// Notices are extra lines shown below the version line, such as Transition.Message (empty entries are skipped)
+	Notices []string
+	// Theme colors the banner with ANSI escape sequences (defaults to no colors)
+	Theme *Theme
+	// TerminalAware makes PrintWithOptions and FprintWithOptions adapt the banner to their output, see BannerForTerminal
+	TerminalAware bool
+	// Detector inspects the output in terminal-aware mode (defaults to DetectTerminal), tests can replace it
+	Detector TerminalDetector
+	// Border selects the box characters (defaults to BorderAsterisk, BorderNone hides the box)
+	Border *BorderStyle
+	// Dividers draws horizontal dividers between the title, version and metadata sections
+	Dividers bool
+	// Metadata adds fields after the Info metadata, a field with an existing key replaces or hides it
+	Metadata []MetadataField
+	// Overflow handles metadata and notices wider than the banner (defaults to OverflowClip)
+	Overflow Overflow
+	// MaxWidth caps the banner width, including border-less and auto-width banners (0 for no limit)
+	MaxWidth int
+	// TitleAlign positions the title (defaults to centered in a box, as written without one)
+	TitleAlign Alignment
+	// VersionAlign positions the version line and notices (defaults to AlignCenter)
+	VersionAlign Alignment
+	// MetadataAlign positions the metadata block, whose values stay lined up (defaults to AlignLeft)
+	MetadataAlign Alignment
+	// Padding is the number of spaces between the box edges and the content (defaults to 1)
+	Padding *int
+	// Margin adds blank lines and indentation around the whole banner
+	Margin BannerMargin
 }
 
 // Banner generates a complete banner with simple text (no ASCII art), auto-width, and metadata
@@ -45,151 +75,15 @@ func BannerWithStyle(appName string, info *Info, style FontStyle) string {
 
 // BannerWithOptions generates a complete banner with customizable options
 func BannerWithOptions(appName string, info *Info, opts BannerOptions) string {
-	// Set defaults
-	// Default ShowBorder based on AutoWidth if not explicitly set
-	showBorder := true
-	if opts.ShowBorder != nil {
-		showBorder = *opts.ShowBorder
-	} else if opts.AutoWidth {
-		showBorder = false // Default to no border for auto-width
-	}
-
-	var lines []string
-	var titleLines []string
-
-	// Generate title lines (ASCII art or simple text)
-	if opts.UseASCII {
-		switch {
-		case opts.AutoWidth:
-			// Generate without width constraint to get natural size
-			titleLines = GenerateASCIIArtWithStyle(appName, 0, opts.FontStyle)
-		case opts.FixedWidth > 0:
-			available := opts.FixedWidth - 4 - paddingLeft*2
-			if available < 0 {
-				available = 0
-			}
-			// Generate with width constraint
-			titleLines = GenerateASCIIArtWithStyle(appName, available, opts.FontStyle)
-		default:
-			// No explicit width - let ASCII art determine width
-			titleLines = GenerateASCIIArtWithStyle(appName, 0, opts.FontStyle)
-		}
-	} else {
-		// Simple text title - split by newlines if multi-line
-		titleLines = splitManualTitleLines(appName)
-	}
-
-	if len(titleLines) == 0 {
-		titleLines = []string{""}
-	}
-
-	// Calculate the longest line in title for centering (trim trailing spaces)
-	maxTitleWidth := longestLineWidth(titleLines)
-	contentWidth := maxContentWidth(titleLines, info)
-
-	// Calculate box width
-	var boxWidth int
-	switch {
-	case opts.AutoWidth:
-		boxWidth = calculateAutoWidth(titleLines, info)
-	case opts.FixedWidth > 0:
-		boxWidth = opts.FixedWidth
-	default:
-		boxWidth = contentWidth + 4
-		if boxWidth < defaultBoxWidth {
-			boxWidth = defaultBoxWidth
-		}
-	}
-
-	// Top border
-	if showBorder {
-		lines = append(lines, strings.Repeat(borderChar, boxWidth))
-		// Empty line after top border
-		lines = append(lines, formatBoxLineWithWidth("", boxWidth))
-	}
-
-		// Add title lines (centered within box when border is shown)
-		for _, line := range titleLines {
-			if showBorder {
-				centered := centerText(line, boxWidth-4)
-				lines = append(lines, formatBoxLineWithWidth(centered, boxWidth))
-			} else {
-				lines = append(lines, line)
-			}
-		}
-
-	// Empty line after title
-	if showBorder {
-		lines = append(lines, formatBoxLineWithWidth("", boxWidth))
-	} else {
-		lines = append(lines, "")
-	}
-
-	// Version line (centered based on longest title line)
-	versionLine := formatVersionLine(info)
-	if showBorder {
-		lines = append(lines, formatBoxLineWithWidth(centerText(versionLine, boxWidth-4), boxWidth))
-	} else {
-		// Center based on the longest title line
-		lines = append(lines, centerText(versionLine, maxTitleWidth))
-	}
-
-	// Empty line after version
-	if showBorder {
-		lines = append(lines, formatBoxLineWithWidth("", boxWidth))
-	} else {
-		lines = append(lines, "")
-	}
-
-	// Metadata lines
-	if info.Author != "" {
-		line := fmt.Sprintf("Author:    %s", info.Author)
-		if showBorder {
-			lines = append(lines, formatBoxLineWithWidth(line, boxWidth))
-		} else {
-			lines = append(lines, line)
-		}
-	}
-	if info.Company != "" {
-		line := fmt.Sprintf("Company:   %s", info.Company)
-		if showBorder {
-			lines = append(lines, formatBoxLineWithWidth(line, boxWidth))
-		} else {
-			lines = append(lines, line)
-		}
-	}
-	if info.Copyright != "" {
-		line := fmt.Sprintf("Copyright: %s", info.Copyright)
-		if showBorder {
-			lines = append(lines, formatBoxLineWithWidth(line, boxWidth))
-		} else {
-			lines = append(lines, line)
-		}
-	}
-	if info.Repo != "" {
-		line := fmt.Sprintf("Repo:      %s", info.Repo)
-		if showBorder {
-			lines = append(lines, formatBoxLineWithWidth(line, boxWidth))
-		} else {
-			lines = append(lines, line)
-		}
-	}
-
-	// Empty line before bottom border (only if we have metadata)
-	if info.Author != "" || info.Company != "" || info.Copyright != "" || info.Repo != "" {
-		if showBorder {
-			lines = append(lines, formatBoxLineWithWidth("", boxWidth))
-		} else {
-			lines = append(lines, "")
-		}
-	}
+	return TextRenderer{}.Render(LayoutBanner(appName, info, opts))
+}
 
-	// Bottom border
-	if showBorder {
-		lines = append(lines, strings.Repeat(borderChar, boxWidth))
+// alignOr returns align, or fallback when align is not set
+func alignOr(align, fallback Alignment) Alignment {
+	if align == "" {
+		return fallback
 	}
-
-	return strings.Join(lines, "\n")
+	return align
 }
 
 // splitManualTitleLines breaks manual multi-line titles into individual lines while preserving indentation.
@@ -221,6 +115,17 @@ func splitManualTitleLines(title string) []string {
 	return lines[start:end]
 }
 
+// nonEmptyLines returns the lines that are not blank
+func nonEmptyLines(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
 // longestLineWidth returns the maximum visible width among provided lines.
 func longestLineWidth(lines []string) int {
 	maxWidth := 0
@@ -233,49 +138,35 @@ func longestLineWidth(lines []string) int {
 	return maxWidth
 }
 
-// visibleWidth calculates the width of a line ignoring trailing whitespace that doesn't affect rendering.
+// visibleWidth calculates the width of a line ignoring escape sequences and trailing whitespace that don't affect rendering.
 func visibleWidth(line string) int {
-	trimmed := strings.TrimRight(line, " \t")
-	return utf8.RuneCountInString(trimmed)
+	trimmed := strings.TrimRight(stripANSI(line), " \t")
+	return stringWidth(trimmed)
 }
 
-// maxContentWidth calculates the maximum width among title, version, and metadata lines.
-func maxContentWidth(titleLines []string, info *Info) int {
+// maxContentWidth calculates the maximum width among title, version, notice, and metadata lines.
+func maxContentWidth(titleLines []string, info *Info, notices, metadata []string) int {
 	maxWidth := longestLineWidth(titleLines)
+	if width := longestLineWidth(notices); width > maxWidth {
+		maxWidth = width
+	}
 
 	versionLine := formatVersionLine(info)
-	if width := utf8.RuneCountInString(versionLine); width > maxWidth {
+	if width := stringWidth(versionLine); width > maxWidth {
 		maxWidth = width
 	}
 
-	if info.Author != "" {
-		if width := utf8.RuneCountInString(fmt.Sprintf("Author:    %s", info.Author)); width > maxWidth {
-			maxWidth = width
-		}
-	}
-	if info.Company != "" {
-		if width := utf8.RuneCountInString(fmt.Sprintf("Company:   %s", info.Company)); width > maxWidth {
-			maxWidth = width
-		}
-	}
-	if info.Copyright != "" {
-		if width := utf8.RuneCountInString(fmt.Sprintf("Copyright: %s", info.Copyright)); width > maxWidth {
-			maxWidth = width
-		}
-	}
-	if info.Repo != "" {
-		if width := utf8.RuneCountInString(fmt.Sprintf("Repo:      %s", info.Repo)); width > maxWidth {
-			maxWidth = width
-		}
+	if width := longestLineWidth(metadata); width > maxWidth {
+		maxWidth = width
 	}
 
 	return maxWidth
 }
 
 // calculateAutoWidth determines the optimal width based on content
-func calculateAutoWidth(titleLines []string, info *Info) int {
-	// Add padding for borders and margins
-	return maxContentWidth(titleLines, info) + 4 // 2 for borders, 2 for padding
+func calculateAutoWidth(titleLines []string, info *Info, notices, metadata []string, overhead int) int {
+	// Add the columns taken by the side edges and the padding next to them
+	return maxContentWidth(titleLines, info, notices, metadata) + overhead
 }
 
 // formatVersionLine creates a formatted version string
@@ -302,31 +193,33 @@ func formatVersionLine(info *Info) string {
 
 // formatBoxLineWithWidth formats a line to fit within the box with borders and padding using specified width
 func formatBoxLineWithWidth(content string, boxWidth int) string {
-	// Calculate available width (excluding borders and padding)
-	availableWidth := boxWidth - 4 // 2 for borders (* *) and 2 for padding (space on each side)
+	return formatBoxLineWithEdges(content, boxWidth, borderChar, borderChar, defaultPadding)
+}
+
+// formatBoxLineWithEdges formats a line to fit within the box between the left and right edges,
+// which may be multi-byte characters and carry escape sequences, with padding spaces next to each edge
+func formatBoxLineWithEdges(content string, boxWidth int, left, right string, padding int) string {
+	// Calculate available width (excluding the edges and the padding on each side)
+	availableWidth := boxWidth - ansiWidth(left) - ansiWidth(right) - 2*padding
 	if availableWidth < 0 {
 		availableWidth = 0
 	}
 
-	// Pad or truncate content to fit while respecting rune boundaries
-	runes := []rune(content)
-	if len(runes) > availableWidth {
-		runes = runes[:availableWidth]
-	}
-
-	trimmed := string(runes)
-	currentWidth := utf8.RuneCountInString(trimmed)
+	// Pad or truncate content to fit while respecting rune boundaries and escape sequences
+	trimmed := truncateANSI(content, availableWidth)
+	currentWidth := ansiWidth(trimmed)
 	if currentWidth < availableWidth {
 		trimmed += strings.Repeat(" ", availableWidth-currentWidth)
 	}
 
 	// Add border and padding
-	return fmt.Sprintf("%s %s %s", borderChar, trimmed, borderChar)
+	space := strings.Repeat(" ", padding)
+	return left + space + trimmed + space + right
 }
 
-// centerText centers text within a given width
+// centerText centers text within a given width, ignoring escape sequences
 func centerText(text string, width int) string {
-	textLen := utf8.RuneCountInString(text)
+	textLen := ansiWidth(text)
 	if textLen >= width || width <= 0 {
 		return text
 	}
@@ -340,52 +233,108 @@ func centerText(text string, width int) string {
 
 // Print prints the banner to stdout using default slant font
 func Print(appName string, info *Info) {
-	fmt.Println(Banner(appName, info))
+	_ = Fprint(os.Stdout, appName, info)
 }
 
 // PrintWithStyle prints the banner to stdout using specified font style
 func PrintWithStyle(appName string, info *Info, style FontStyle) {
-	fmt.Println(BannerWithStyle(appName, info, style))
+	_ = FprintWithStyle(os.Stdout, appName, info, style)
 }
 
 // PrintWithOptions prints the banner to stdout using custom options
 func PrintWithOptions(appName string, info *Info, opts BannerOptions) {
-	fmt.Println(BannerWithOptions(appName, info, opts))
+	_ = FprintWithOptions(os.Stdout, appName, info, opts)
+}
+
+// Fprint writes the banner to w using default slant font and returns any write error
+func Fprint(w io.Writer, appName string, info *Info) error {
+	_, err := fmt.Fprintln(w, Banner(appName, info))
+	return err
+}
+
+// FprintWithStyle writes the banner to w using specified font style and returns any write error
+func FprintWithStyle(w io.Writer, appName string, info *Info, style FontStyle) error {
+	_, err := fmt.Fprintln(w, BannerWithStyle(appName, info, style))
+	return err
+}
+
+// FprintWithOptions writes the banner to w using custom options and returns any write error
+// In terminal-aware mode the banner is adapted to w, see BannerForTerminal.
+func FprintWithOptions(w io.Writer, appName string, info *Info, opts BannerOptions) error {
+	var banner string
+	if opts.TerminalAware {
+		detect := opts.Detector
+		if detect == nil {
+			detect = DetectTerminal
+		}
+		banner = BannerForTerminal(appName, info, opts, detect(w))
+	} else {
+		banner = BannerWithOptions(appName, info, opts)
+	}
+	_, err := fmt.Fprintln(w, banner)
+	return err
+}
+
+// QuickPrintOption customizes the QuickPrint helpers
+type QuickPrintOption func(*quickPrintConfig)
+
+// quickPrintConfig holds the settings changed by QuickPrintOption
+type quickPrintConfig struct {
+	writer io.Writer
+}
+
+// WithWriter makes the QuickPrint helpers write the banner to w instead of stdout
+func WithWriter(w io.Writer) QuickPrintOption {
+	return func(c *quickPrintConfig) {
+		c.writer = w
+	}
 }
 
 // QuickPrint is a convenience function that parses version string and prints banner using default slant font
-func QuickPrint(appName, versionStr, author, company, copyright, repo string) error {
-	return QuickPrintWithStyle(appName, versionStr, author, company, copyright, repo, FontStyleSlant)
+func QuickPrint(appName, versionStr, author, company, copyright, repo string, options ...QuickPrintOption) error {
+	return QuickPrintWithStyle(appName, versionStr, author, company, copyright, repo, FontStyleSlant, options...)
 }
 
 // QuickPrintWithStyle is a convenience function that parses version string and prints banner using specified font style
-func QuickPrintWithStyle(appName, versionStr, author, company, copyright, repo string, style FontStyle) error {
-	info, err := Parse(versionStr)
+func QuickPrintWithStyle(appName, versionStr, author, company, copyright, repo string, style FontStyle, options ...QuickPrintOption) error {
+	info, err := quickInfo(versionStr, author, company, copyright, repo)
 	if err != nil {
 		return err
 	}
-
-	info.Author = author
-	info.Company = company
-	info.Copyright = copyright
-	info.Repo = repo
-
-	PrintWithStyle(appName, info, style)
-	return nil
+	return FprintWithStyle(quickWriter(options), appName, info, style)
 }
 
 // QuickPrintWithOptions is a convenience function that parses version string and prints banner using custom options
-func QuickPrintWithOptions(appName, versionStr, author, company, copyright, repo string, opts BannerOptions) error {
-	info, err := Parse(versionStr)
+func QuickPrintWithOptions(appName, versionStr, author, company, copyright, repo string, opts BannerOptions, options ...QuickPrintOption) error {
+	info, err := quickInfo(versionStr, author, company, copyright, repo)
 	if err != nil {
 		return err
 	}
+	return FprintWithOptions(quickWriter(options), appName, info, opts)
+}
+
+// quickInfo parses versionStr and fills the metadata used by the QuickPrint helpers
+func quickInfo(versionStr, author, company, copyright, repo string) (*Info, error) {
+	info, err := Parse(versionStr)
+	if err != nil {
+		return nil, err
+	}
 
 	info.Author = author
 	info.Company = company
 	info.Copyright = copyright
 	info.Repo = repo
+	return info, nil
+}
 
-	PrintWithOptions(appName, info, opts)
-	return nil
+// quickWriter applies options and returns the writer to print to (defaults to os.Stdout)
+func quickWriter(options []QuickPrintOption) io.Writer {
+	config := quickPrintConfig{writer: os.Stdout}
+	for _, option := range options {
+		option(&config)
+	}
+	if config.writer == nil {
+		return os.Stdout
+	}
+	return config.writer
 }
@@ -1,6 +1,9 @@
 package version
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 	"unicode/utf8"
@@ -133,3 +136,67 @@ func TestBannerKeepsMultibyteCharactersIntact(t *testing.T) {
 		}
 	}
 }
+
+func TestFprint(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Author: "Jane"}
+	tests := []struct {
+		name  string
+		print func(w io.Writer) error
+		want  string
+	}{
+		{name: "Fprint", print: func(w io.Writer) error { return Fprint(w, "App", info) }, want: Banner("App", info) + "\n"},
+		{name: "FprintWithStyle", print: func(w io.Writer) error { return FprintWithStyle(w, "App", info, FontStyleBig) }, want: BannerWithStyle("App", info, FontStyleBig) + "\n"},
+		{
+			name:  "FprintWithOptions",
+			print: func(w io.Writer) error { return FprintWithOptions(w, "App", info, BannerOptions{FixedWidth: 30}) },
+			want:  BannerWithOptions("App", info, BannerOptions{FixedWidth: 30}) + "\n",
+		},
+		{
+			name: "FprintWithOptions terminal aware",
+			print: func(w io.Writer) error {
+				return FprintWithOptions(w, "App", info, BannerOptions{TerminalAware: true, Detector: func(io.Writer) Terminal { return Terminal{} }})
+			},
+			want: "App v1.2.3\n",
+		},
+		{name: "QuickPrint", print: func(w io.Writer) error { return QuickPrint("App", "1.2.3", "Jane", "", "", "", WithWriter(w)) }, want: Banner("App", info) + "\n"},
+		{
+			name: "QuickPrintWithOptions",
+			print: func(w io.Writer) error {
+				return QuickPrintWithOptions("App", "1.2.3", "Jane", "", "", "", BannerOptions{FixedWidth: 30}, WithWriter(w))
+			},
+			want: BannerWithOptions("App", info, BannerOptions{FixedWidth: 30}) + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.print(&buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("wrote %q, want %q", buf.String(), tt.want)
+			}
+
+			if err := tt.print(failingWriter{}); !errors.Is(err, errWriteFailed) {
+				t.Errorf("error = %v, want %v", err, errWriteFailed)
+			}
+		})
+	}
+}
+
+func TestQuickPrintInvalidVersion(t *testing.T) {
+	var buf bytes.Buffer
+	if err := QuickPrint("App", "nope", "", "", "", "", WithWriter(&buf)); err == nil || buf.Len() != 0 {
+		t.Errorf("QuickPrint() error = %v, wrote %q, want a parse error and no output", err, buf.String())
+	}
+}
+
+var errWriteFailed = errors.New("write failed")
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriteFailed
+}
@@ -0,0 +1,119 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BorderStyle holds the characters drawing the banner box
+// Each field is normally a single character; wider strings and multi-byte box drawing characters are
+// measured by their visible width.
+type BorderStyle struct {
+	TopLeft     string
+	Top         string
+	TopRight    string
+	Left        string
+	Right       string
+	BottomLeft  string
+	Bottom      string
+	BottomRight string
+	// DividerLeft, Divider and DividerRight draw the horizontal dividers between sections
+	DividerLeft  string
+	Divider      string
+	DividerRight string
+}
+
+var (
+	// BorderAsterisk is the classic *** box
+	BorderAsterisk = uniformBorder(borderChar)
+	// BorderASCII draws +-| boxes
+	BorderASCII = BorderStyle{
+		TopLeft: "+", Top: "-", TopRight: "+", Left: "|", Right: "|", BottomLeft: "+", Bottom: "-", BottomRight: "+",
+		DividerLeft: "+", Divider: "-", DividerRight: "+",
+	}
+	// BorderSingle draws single line Unicode boxes
+	BorderSingle = BorderStyle{
+		TopLeft: "┌", Top: "─", TopRight: "┐", Left: "│", Right: "│", BottomLeft: "└", Bottom: "─", BottomRight: "┘",
+		DividerLeft: "├", Divider: "─", DividerRight: "┤",
+	}
+	// BorderDouble draws double line Unicode boxes
+	BorderDouble = BorderStyle{
+		TopLeft: "╔", Top: "═", TopRight: "╗", Left: "║", Right: "║", BottomLeft: "╚", Bottom: "═", BottomRight: "╝",
+		DividerLeft: "╠", Divider: "═", DividerRight: "╣",
+	}
+	// BorderRounded draws single line Unicode boxes with rounded corners
+	BorderRounded = BorderStyle{
+		TopLeft: "╭", Top: "─", TopRight: "╮", Left: "│", Right: "│", BottomLeft: "╰", Bottom: "─", BottomRight: "╯",
+		DividerLeft: "├", Divider: "─", DividerRight: "┤",
+	}
+	// BorderHeavy draws heavy line Unicode boxes
+	BorderHeavy = BorderStyle{
+		TopLeft: "┏", Top: "━", TopRight: "┓", Left: "┃", Right: "┃", BottomLeft: "┗", Bottom: "━", BottomRight: "┛",
+		DividerLeft: "┣", Divider: "━", DividerRight: "┫",
+	}
+	// BorderNone draws no box at all
+	BorderNone = BorderStyle{}
+)
+
+// borderStyles maps the preset names accepted by BorderStyleByName
+var borderStyles = map[string]BorderStyle{
+	"asterisk": BorderAsterisk,
+	"ascii":    BorderASCII,
+	"single":   BorderSingle,
+	"double":   BorderDouble,
+	"rounded":  BorderRounded,
+	"heavy":    BorderHeavy,
+	"none":     BorderNone,
+}
+
+// BorderStyleByName returns a preset by name: asterisk, ascii, single, double, rounded, heavy or none
+func BorderStyleByName(name string) (BorderStyle, error) {
+	style, ok := borderStyles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(borderStyles))
+		for n := range borderStyles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return BorderStyle{}, fmt.Errorf("invalid border style: %s (expected: %s)", name, strings.Join(names, ", "))
+	}
+	return style, nil
+}
+
+// IsNone returns true when the style draws nothing
+func (b BorderStyle) IsNone() bool {
+	return b == BorderStyle{}
+}
+
+// uniformBorder returns a style using char everywhere
+func uniformBorder(char string) BorderStyle {
+	return BorderStyle{
+		TopLeft: char, Top: char, TopRight: char, Left: char, Right: char, BottomLeft: char, Bottom: char, BottomRight: char,
+		DividerLeft: char, Divider: char, DividerRight: char,
+	}
+}
+
+// overhead returns the columns taken by the side edges and padding spaces next to each of them
+func (b BorderStyle) overhead(padding int) int {
+	return ansiWidth(b.Left) + ansiWidth(b.Right) + 2*padding
+}
+
+// horizontalLine draws left, fill repeated and right over exactly width columns
+func horizontalLine(left, fill, right string, width int) string {
+	fillWidth := width - ansiWidth(left) - ansiWidth(right)
+	if fillWidth <= 0 {
+		return truncateANSI(left+right, width)
+	}
+	return left + repeatToWidth(fill, fillWidth) + right
+}
+
+// repeatToWidth repeats s until it covers width columns, cutting the last repetition if needed
+func repeatToWidth(s string, width int) string {
+	sWidth := ansiWidth(s)
+	if sWidth == 0 {
+		return strings.Repeat(" ", width)
+	}
+	line := truncateANSI(strings.Repeat(s, width/sWidth+1), width)
+	return line + strings.Repeat(" ", width-ansiWidth(line)) // a wide fill character may leave a column
+}
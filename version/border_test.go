@@ -0,0 +1,100 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBannerBorderStyles(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Author: "Jane Doe"}
+	presets := map[string]BorderStyle{
+		"asterisk": BorderAsterisk,
+		"ascii":    BorderASCII,
+		"single":   BorderSingle,
+		"double":   BorderDouble,
+		"rounded":  BorderRounded,
+		"heavy":    BorderHeavy,
+	}
+
+	showBorder := true
+
+	for name, style := range presets {
+		t.Run(name, func(t *testing.T) {
+			for _, autoWidth := range []bool{false, true} {
+				style := style
+				got := BannerWithOptions("App", info, BannerOptions{FixedWidth: 40, AutoWidth: autoWidth, ShowBorder: &showBorder, Border: &style, Dividers: true})
+				lines := strings.Split(got, "\n")
+				width := ansiWidth(lines[0])
+				for i, line := range lines {
+					if ansiWidth(line) != width {
+						t.Errorf("line %d width = %d, want %d:\n%s", i, ansiWidth(line), width, got)
+					}
+				}
+				if !strings.HasPrefix(lines[0], style.TopLeft) || !strings.HasSuffix(lines[0], style.TopRight) {
+					t.Errorf("top border = %q, want %s...%s", lines[0], style.TopLeft, style.TopRight)
+				}
+				if last := lines[len(lines)-1]; !strings.HasPrefix(last, style.BottomLeft) || !strings.HasSuffix(last, style.BottomRight) {
+					t.Errorf("bottom border = %q, want %s...%s", last, style.BottomLeft, style.BottomRight)
+				}
+				if dividers := strings.Count(got, style.DividerLeft+style.Divider); dividers < 2 {
+					t.Errorf("found %d dividers, want 2:\n%s", dividers, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBannerBorderNone(t *testing.T) {
+	showBorder := true
+	style := BorderNone
+	got := BannerWithOptions("App", &Info{Major: 1, Author: "Jane Doe"}, BannerOptions{FixedWidth: 40, ShowBorder: &showBorder, Border: &style, Dividers: true})
+	if strings.Contains(got, borderChar) {
+		t.Errorf("BorderNone should hide the box, got:\n%s", got)
+	}
+	if dividers := strings.Count(got, "\n"+strings.Repeat("-", 19)+"\n"); dividers != 2 {
+		t.Errorf("dividers should fall back to dashes, got:\n%s", got)
+	}
+}
+
+func TestBannerCustomBorder(t *testing.T) {
+	showBorder := true
+	style := BorderStyle{TopLeft: "<<", Top: "=-", TopRight: ">>", Left: "[", Right: "]", BottomLeft: "<<", Bottom: "=", BottomRight: ">>"}
+	got := BannerWithOptions("App", &Info{Major: 1}, BannerOptions{FixedWidth: 31, ShowBorder: &showBorder, Border: &style})
+	lines := strings.Split(got, "\n")
+
+	if want := "<<=-=-=-=-=-=-=-=-=-=-=-=-=-=>>"; lines[0] != want {
+		t.Errorf("top border = %q, want %q", lines[0], want)
+	}
+	for i, line := range lines {
+		if ansiWidth(line) != 31 {
+			t.Errorf("line %d width = %d, want 31", i, ansiWidth(line))
+		}
+	}
+	if !strings.HasPrefix(lines[1], "[ ") || !strings.HasSuffix(lines[1], " ]") {
+		t.Errorf("box line = %q, want [ ... ]", lines[1])
+	}
+}
+
+func TestBannerThemedBorder(t *testing.T) {
+	showBorder := true
+	style := BorderRounded
+	theme := &Theme{Border: "cyan", Profile: ColorProfileANSI}
+	got := BannerWithOptions("App", &Info{Major: 1}, BannerOptions{FixedWidth: 30, ShowBorder: &showBorder, Border: &style, Theme: theme})
+	lines := strings.Split(got, "\n")
+
+	for _, line := range []string{lines[0], lines[len(lines)-1]} {
+		if !strings.HasPrefix(line, "\x1b[36m") {
+			t.Errorf("border line %q should be painted", line)
+		}
+	}
+}
+
+func TestBorderStyleByName(t *testing.T) {
+	got, err := BorderStyleByName(" Double ")
+	if err != nil || got != BorderDouble {
+		t.Errorf("BorderStyleByName(\"Double\") = %+v, %v, want BorderDouble", got, err)
+	}
+	if _, err := BorderStyleByName("dotted"); err == nil || !strings.Contains(err.Error(), "invalid border style") {
+		t.Errorf("BorderStyleByName(\"dotted\") error = %v, want invalid border style", err)
+	}
+}
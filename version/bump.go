@@ -0,0 +1,44 @@
+package version
+
+import "fmt"
+
+// Part identifies a numeric component of a version
+type Part string
+
+const (
+	// PartMajor - the major component (X in X.Y.Z)
+	PartMajor Part = "major"
+	// PartMinor - the minor component (Y in X.Y.Z)
+	PartMinor Part = "minor"
+	// PartPatch - the patch component (Z in X.Y.Z)
+	PartPatch Part = "patch"
+)
+
+// Bump returns a copy of the version with the given part incremented and the lower parts reset to zero.
+// The result is a release version: hash and suffix are cleared, metadata such as Author is kept.
+// Examples:
+//   - "1.2.3" bump minor         -> "1.3.0"
+//   - "1.2.3:ABC-dev" bump patch -> "1.2.4"
+func (i *Info) Bump(part Part) (*Info, error) {
+	next := *i
+	next.Metadata = append([]MetadataField(nil), i.Metadata...)
+	next.Hash = ""
+	next.Suffix = ""
+
+	switch part {
+	case PartMajor:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case PartMinor:
+		next.Minor++
+		next.Patch = 0
+	case PartPatch:
+		next.Patch++
+	default:
+		return nil, fmt.Errorf("invalid version part: %s (expected: major, minor or patch)", part)
+	}
+
+	next.Version = formatVersionString(next.Major, next.Minor, next.Patch, "", "")
+	return &next, nil
+}
@@ -0,0 +1,217 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const catalogDateLayout = "2006-01-02"
+
+// SupportStatus is the lifecycle phase of a release line
+type SupportStatus string
+
+const (
+	// SupportStatusSupported - the line receives bug and security fixes
+	SupportStatusSupported SupportStatus = "supported"
+	// SupportStatusSecurityOnly - the line only receives security fixes
+	SupportStatusSecurityOnly SupportStatus = "security-only"
+	// SupportStatusEOL - the line is end-of-life and receives no fixes
+	SupportStatusEOL SupportStatus = "eol"
+	// SupportStatusUnknown - the version is not listed in the catalog
+	SupportStatusUnknown SupportStatus = "unknown"
+)
+
+// ReleaseLine is a minor release line, such as 1.4, listed in a catalog
+type ReleaseLine struct {
+	Major    int
+	Minor    int
+	Codename string
+	// ReleaseDate is when the line was first released
+	ReleaseDate time.Time
+	// SecurityOnlyDate is when the line stops receiving bug fixes, zero if it goes straight to end-of-life
+	SecurityOnlyDate time.Time
+	// EOLDate is when the line stops receiving any fix, zero if not scheduled yet
+	EOLDate time.Time
+}
+
+// Catalog lists the release lines of an application with their codenames and support lifecycle
+type Catalog struct {
+	// Lines are sorted by version
+	Lines []ReleaseLine
+}
+
+// catalogFile is the releases.json document read by ParseCatalog
+// Example:
+//
+//	{"releases": [{"line": "1.4", "codename": "Aurora", "release_date": "2025-01-15",
+//	  "security_only_date": "2025-07-15", "eol_date": "2026-01-15"}]}
+type catalogFile struct {
+	Releases []struct {
+		Line             string `json:"line"`
+		Codename         string `json:"codename"`
+		ReleaseDate      string `json:"release_date"`
+		SecurityOnlyDate string `json:"security_only_date"`
+		EOLDate          string `json:"eol_date"`
+	} `json:"releases"`
+}
+
+// ParseCatalog parses a releases.json document, dates being formatted as 2006-01-02 or RFC 3339
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var file catalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid release catalog: %w", err)
+	}
+
+	catalog := &Catalog{}
+	seen := map[string]bool{}
+	for _, entry := range file.Releases {
+		line := ReleaseLine{Codename: entry.Codename}
+
+		var err error
+		line.Major, line.Minor, err = parseLineName(entry.Line)
+		if err != nil {
+			return nil, err
+		}
+		name := line.Name()
+		if seen[name] {
+			return nil, fmt.Errorf("release line %s is listed twice", name)
+		}
+		seen[name] = true
+
+		if line.ReleaseDate, err = parseCatalogDate(entry.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("release line %s: invalid release_date: %w", name, err)
+		}
+		if line.SecurityOnlyDate, err = parseCatalogDate(entry.SecurityOnlyDate); err != nil {
+			return nil, fmt.Errorf("release line %s: invalid security_only_date: %w", name, err)
+		}
+		if line.EOLDate, err = parseCatalogDate(entry.EOLDate); err != nil {
+			return nil, fmt.Errorf("release line %s: invalid eol_date: %w", name, err)
+		}
+		if !line.SecurityOnlyDate.IsZero() && !line.EOLDate.IsZero() && line.SecurityOnlyDate.After(line.EOLDate) {
+			return nil, fmt.Errorf("release line %s: security_only_date is after eol_date", name)
+		}
+
+		catalog.Lines = append(catalog.Lines, line)
+	}
+
+	sort.Slice(catalog.Lines, func(a, b int) bool {
+		if catalog.Lines[a].Major != catalog.Lines[b].Major {
+			return catalog.Lines[a].Major < catalog.Lines[b].Major
+		}
+		return catalog.Lines[a].Minor < catalog.Lines[b].Minor
+	})
+	return catalog, nil
+}
+
+// LoadCatalog reads a releases.json file from disk
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read release catalog: %w", err)
+	}
+	return ParseCatalog(data)
+}
+
+// LoadCatalogFS reads a releases.json file from fsys, typically an embed.FS
+func LoadCatalogFS(fsys fs.FS, name string) (*Catalog, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read release catalog: %w", err)
+	}
+	return ParseCatalog(data)
+}
+
+// Line returns the release line info belongs to
+func (c *Catalog) Line(info *Info) (*ReleaseLine, bool) {
+	if c == nil || info == nil {
+		return nil, false
+	}
+	for idx := range c.Lines {
+		if c.Lines[idx].Major == info.Major && c.Lines[idx].Minor == info.Minor {
+			return &c.Lines[idx], true
+		}
+	}
+	return nil, false
+}
+
+// SupportStatus returns the lifecycle phase of the line info belongs to, SupportStatusUnknown if it is not listed
+func (c *Catalog) SupportStatus(info *Info, now time.Time) SupportStatus {
+	line, ok := c.Line(info)
+	if !ok {
+		return SupportStatusUnknown
+	}
+	return line.SupportStatus(now)
+}
+
+// Notices returns banner lines for info: the codename of its line and a warning when the line is
+// security-only or end-of-life. Pass them to BannerOptions.Notices.
+func (c *Catalog) Notices(info *Info, now time.Time) []string {
+	line, ok := c.Line(info)
+	if !ok {
+		return nil
+	}
+
+	var notices []string
+	if line.Codename != "" {
+		notices = append(notices, `"`+line.Codename+`"`)
+	}
+	switch line.SupportStatus(now) {
+	case SupportStatusSecurityOnly:
+		if line.EOLDate.IsZero() {
+			notices = append(notices, "This version only receives security fixes")
+		} else {
+			notices = append(notices, fmt.Sprintf("This version only receives security fixes until %s", line.EOLDate.Format(catalogDateLayout)))
+		}
+	case SupportStatusEOL:
+		notices = append(notices, fmt.Sprintf("This version is end-of-life since %s, please upgrade", line.EOLDate.Format(catalogDateLayout)))
+	}
+	return notices
+}
+
+// Name returns the line name, such as "1.4"
+func (l *ReleaseLine) Name() string {
+	return fmt.Sprintf("%d.%d", l.Major, l.Minor)
+}
+
+// SupportStatus returns the lifecycle phase of the line at now
+func (l *ReleaseLine) SupportStatus(now time.Time) SupportStatus {
+	switch {
+	case !l.EOLDate.IsZero() && !now.Before(l.EOLDate):
+		return SupportStatusEOL
+	case !l.SecurityOnlyDate.IsZero() && !now.Before(l.SecurityOnlyDate):
+		return SupportStatusSecurityOnly
+	default:
+		return SupportStatusSupported
+	}
+}
+
+// parseLineName parses a line name such as "1.4" (a leading v is accepted)
+func parseLineName(name string) (int, int, error) {
+	majorStr, minorStr, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(name), "v"), ".")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid release line: %q (expected: MAJOR.MINOR)", name)
+	}
+	major, errMajor := strconv.Atoi(majorStr)
+	minor, errMinor := strconv.Atoi(minorStr)
+	if errMajor != nil || errMinor != nil || major < 0 || minor < 0 {
+		return 0, 0, fmt.Errorf("invalid release line: %q (expected: MAJOR.MINOR)", name)
+	}
+	return major, minor, nil
+}
+
+// parseCatalogDate parses a 2006-01-02 or RFC 3339 date, an empty string giving the zero time
+func parseCatalogDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(catalogDateLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,128 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testCatalog = `{"releases": [
+	{"line": "1.5", "codename": "Borealis", "release_date": "2025-07-01"},
+	{"line": "1.4", "codename": "Aurora", "release_date": "2025-01-15", "security_only_date": "2025-07-15", "eol_date": "2026-01-15"},
+	{"line": "v1.3", "release_date": "2024-07-01", "eol_date": "2025-01-15T00:00:00Z"}
+]}`
+
+func TestCatalogSupportStatus(t *testing.T) {
+	catalog, err := LoadCatalogFS(fstest.MapFS{"releases.json": {Data: []byte(testCatalog)}}, "releases.json")
+	if err != nil {
+		t.Fatalf("LoadCatalogFS() unexpected error: %v", err)
+	}
+	if len(catalog.Lines) != 3 || catalog.Lines[0].Name() != "1.3" || catalog.Lines[2].Name() != "1.5" {
+		t.Fatalf("Lines = %+v, want 1.3, 1.4 and 1.5 sorted", catalog.Lines)
+	}
+
+	tests := []struct {
+		version string
+		now     string
+		want    SupportStatus
+	}{
+		{version: "1.4.2", now: "2025-03-01", want: SupportStatusSupported},
+		{version: "1.4.2-beta", now: "2025-07-15", want: SupportStatusSecurityOnly},
+		{version: "1.4.0", now: "2026-01-15", want: SupportStatusEOL},
+		{version: "1.3.9", now: "2024-12-31", want: SupportStatusSupported},
+		{version: "1.3.9", now: "2025-02-01", want: SupportStatusEOL},
+		{version: "1.5.0", now: "2030-01-01", want: SupportStatusSupported},
+		{version: "2.0.0", now: "2025-01-01", want: SupportStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse("2006-01-02", tt.now)
+		if got := catalog.SupportStatus(mustParse(t, tt.version), now); got != tt.want {
+			t.Errorf("SupportStatus(%s, %s) = %q, want %q", tt.version, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogNotices(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatalf("ParseCatalog() unexpected error: %v", err)
+	}
+	info := mustParse(t, "1.4.1")
+
+	tests := []struct {
+		now  string
+		want []string
+	}{
+		{now: "2025-03-01", want: []string{`"Aurora"`}},
+		{now: "2025-08-01", want: []string{`"Aurora"`, "This version only receives security fixes until 2026-01-15"}},
+		{now: "2026-02-01", want: []string{`"Aurora"`, "This version is end-of-life since 2026-01-15, please upgrade"}},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse("2006-01-02", tt.now)
+		if got := catalog.Notices(info, now); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Notices(%s) = %q, want %q", tt.now, got, tt.want)
+		}
+	}
+
+	now, _ := time.Parse("2006-01-02", "2026-02-01")
+	banner := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, Notices: catalog.Notices(info, now)})
+	if !strings.Contains(banner, "end-of-life") {
+		t.Errorf("banner should show the end-of-life warning, got:\n%s", banner)
+	}
+
+	if notices := catalog.Notices(mustParse(t, "9.0.0"), now); notices != nil {
+		t.Errorf("Notices() for an unlisted version = %q, want nil", notices)
+	}
+
+	// The codename is shown as written, without Go escaping
+	quoted, err := ParseCatalog([]byte(`{"releases": [{"line": "2.0", "codename": "Ölfjord \"Nord\""}]}`))
+	if err != nil {
+		t.Fatalf("ParseCatalog() unexpected error: %v", err)
+	}
+	if got, want := quoted.Notices(mustParse(t, "2.0.0"), now), []string{`"Ölfjord "Nord""`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Notices() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCatalogInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+	}{
+		{name: "invalid json", catalog: `{"releases": [`},
+		{name: "invalid line", catalog: `{"releases": [{"line": "1"}]}`},
+		{name: "duplicate line", catalog: `{"releases": [{"line": "1.0"}, {"line": "v1.0"}]}`},
+		{name: "invalid date", catalog: `{"releases": [{"line": "1.0", "eol_date": "next year"}]}`},
+		{name: "security-only after eol", catalog: `{"releases": [{"line": "1.0", "security_only_date": "2025-02-01", "eol_date": "2025-01-01"}]}`},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseCatalog([]byte(tt.catalog)); err == nil {
+			t.Errorf("%s: ParseCatalog() expected error but got none", tt.name)
+		}
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "releases.json")
+	if err := os.WriteFile(path, []byte(testCatalog), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	catalog, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog() unexpected error: %v", err)
+	}
+	if line, ok := catalog.Line(mustParse(t, "1.5.3")); !ok || line.Codename != "Borealis" {
+		t.Errorf("Line(1.5.3) = %+v, %t, want Borealis", line, ok)
+	}
+
+	if _, err := LoadCatalog(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadCatalog() with missing file expected error but got none")
+	}
+}
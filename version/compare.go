@@ -0,0 +1,98 @@
+package version
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compare returns -1 if a is lower than b, 0 if both have the same precedence and 1 if a is greater than b.
+// Major, minor and patch are compared numerically, then a version without suffix ranks above any pre-release.
+// Suffixes rank canary < dev < alpha < beta < rcN, with release candidates ordered by their number.
+// The hash does not take part in the comparison.
+func Compare(a, b *Info) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if c := compareCore(a, b); c != 0 {
+		return c
+	}
+
+	return compareSuffix(a.Suffix, b.Suffix)
+}
+
+// Compare compares this version with other, see Compare for the precedence rules
+func (i *Info) Compare(other *Info) int {
+	return Compare(i, other)
+}
+
+// LessThan returns true if this version has a lower precedence than other
+func (i *Info) LessThan(other *Info) bool {
+	return Compare(i, other) < 0
+}
+
+// GreaterThan returns true if this version has a higher precedence than other
+func (i *Info) GreaterThan(other *Info) bool {
+	return Compare(i, other) > 0
+}
+
+// Equal returns true if both versions have the same precedence (hashes are ignored)
+func (i *Info) Equal(other *Info) bool {
+	return Compare(i, other) == 0
+}
+
+// Sort sorts versions in ascending precedence order, keeping the original order of equal versions
+func Sort(versions []*Info) {
+	sort.SliceStable(versions, func(a, b int) bool {
+		return Compare(versions[a], versions[b]) < 0
+	})
+}
+
+// compareCore compares the numeric major, minor and patch components only
+func compareCore(a, b *Info) int {
+	switch {
+	case a.Major != b.Major:
+		return compareInt(a.Major, b.Major)
+	case a.Minor != b.Minor:
+		return compareInt(a.Minor, b.Minor)
+	default:
+		return compareInt(a.Patch, b.Patch)
+	}
+}
+
+// compareSuffix orders build suffixes, an empty suffix (release) being the highest
+func compareSuffix(a, b string) int {
+	rankA, numA := suffixRank(a)
+	rankB, numB := suffixRank(b)
+	if rankA != rankB {
+		return compareInt(rankA, rankB)
+	}
+	return compareInt(numA, numB)
+}
+
+// suffixRank returns the precedence of a suffix and, for release candidates, its number
+func suffixRank(suffix string) (int, int) {
+	stability := stabilityOf(suffix)
+	if stability != StabilityRC {
+		return stability.rank(), 0
+	}
+	n, _ := strconv.Atoi(strings.TrimPrefix(strings.ToLower(suffix), "rc"))
+	return stability.rank(), n
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,166 @@
+package version
+
+import "testing"
+
+func mustParse(t *testing.T, versionStr string) *Info {
+	t.Helper()
+	info, err := Parse(versionStr)
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", versionStr, err)
+	}
+	return info
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "1.2.3", b: "1.2.3", want: 0},
+		{a: "1.2.3", b: "1.2.4", want: -1},
+		{a: "1.3.0", b: "1.2.9", want: 1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "1.0.0-beta", b: "1.0.0", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-beta", want: -1},
+		{a: "1.0.0-rc1", b: "1.0.0-beta", want: 1},
+		{a: "1.0.0-rc2", b: "1.0.0-rc10", want: -1},
+		{a: "1.0.0-canary", b: "1.0.0-dev", want: -1},
+		{a: "1.0.0-dev", b: "1.0.0-alpha", want: -1},
+		{a: "1.0.0:ABC", b: "1.0.0:DEF", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, b := mustParse(t, tt.a), mustParse(t, tt.b)
+			if got := Compare(a, b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Compare(b, a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareNil(t *testing.T) {
+	info := &Info{Major: 1}
+	if got := Compare(nil, nil); got != 0 {
+		t.Errorf("Compare(nil, nil) = %d, want 0", got)
+	}
+	if got := Compare(nil, info); got != -1 {
+		t.Errorf("Compare(nil, info) = %d, want -1", got)
+	}
+	if !info.GreaterThan(nil) {
+		t.Errorf("GreaterThan(nil) = false, want true")
+	}
+}
+
+func TestSort(t *testing.T) {
+	input := []string{"1.0.0", "0.9.0", "1.0.0-rc1", "1.0.0-beta", "0.10.0", "1.0.0-canary"}
+	want := []string{"0.9.0", "0.10.0", "1.0.0-canary", "1.0.0-beta", "1.0.0-rc1", "1.0.0"}
+
+	var versions []*Info
+	for _, v := range input {
+		versions = append(versions, mustParse(t, v))
+	}
+
+	Sort(versions)
+
+	for idx, info := range versions {
+		if info.String() != want[idx] {
+			t.Errorf("Sort()[%d] = %q, want %q", idx, info.String(), want[idx])
+		}
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "1.2.3", version: "1.2.3", want: true},
+		{constraint: "=1.2.3", version: "1.2.4", want: false},
+		{constraint: "!=1.2.3", version: "1.2.4", want: true},
+		{constraint: ">=1.2.0", version: "1.2.0", want: true},
+		{constraint: ">1.2.0", version: "1.2.0", want: false},
+		{constraint: "<2.0.0", version: "2.0.0-beta", want: true},
+		{constraint: "<=1.0.0", version: "1.0.1", want: false},
+		{constraint: ">=1.2.0, <2.0.0", version: "1.9.9", want: true},
+		{constraint: ">=1.2.0 <2.0.0", version: "2.0.0", want: false},
+		{constraint: ">= 1.2.0", version: "1.3.0", want: true},
+		{constraint: "~1.2.3", version: "1.2.9", want: true},
+		{constraint: "~1.2.3", version: "1.3.0", want: false},
+		{constraint: "^1.2.3", version: "1.9.0", want: true},
+		{constraint: "^1.2.3", version: "2.0.0-beta", want: false},
+		{constraint: "^0.2.3", version: "0.3.0", want: false},
+		{constraint: "^0.0.3", version: "0.0.4", want: false},
+		{constraint: "^1.0.0 || ^3.0.0", version: "3.1.0", want: true},
+		{constraint: "^1.0.0 || ^3.0.0", version: "2.1.0", want: false},
+		{constraint: "*", version: "0.0.1-dev", want: true},
+		{constraint: ">=1.0.0-beta", version: "1.0.0-alpha", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			got, err := mustParse(t, tt.version).Satisfies(tt.constraint)
+			if err != nil {
+				t.Fatalf("Satisfies() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Satisfies(%q) = %t, want %t", tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, constraint := range []string{"", ">=", "1.2", ">=1.0.0 ||", "~abc"} {
+		if _, err := ParseConstraint(constraint); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error but got none", constraint)
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		version string
+		part    Part
+		want    string
+	}{
+		{version: "1.2.3", part: PartMajor, want: "2.0.0"},
+		{version: "1.2.3", part: PartMinor, want: "1.3.0"},
+		{version: "1.2.3", part: PartPatch, want: "1.2.4"},
+		{version: "1.2.3:ABC-dev", part: PartPatch, want: "1.2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+string(tt.part), func(t *testing.T) {
+			info := mustParse(t, tt.version)
+			info.Author = "Jane"
+
+			got, err := info.Bump(tt.part)
+			if err != nil {
+				t.Fatalf("Bump() unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Bump(%s) = %q, want %q", tt.part, got.String(), tt.want)
+			}
+			if got.Hash != "" || got.Suffix != "" {
+				t.Errorf("Bump() kept hash %q / suffix %q, want both cleared", got.Hash, got.Suffix)
+			}
+			if got.Author != "Jane" {
+				t.Errorf("Author = %q, want %q", got.Author, "Jane")
+			}
+			if info.String() != tt.version {
+				t.Errorf("Bump() modified the receiver: %q", info.String())
+			}
+		})
+	}
+
+	if _, err := (&Info{}).Bump("build"); err == nil {
+		t.Errorf("Bump(build) expected error but got none")
+	}
+}
@@ -0,0 +1,162 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a parsed version range that can be checked against an Info
+// Supported syntax:
+//   - ">=1.2.0"              -> comparison operators: =, ==, !=, >, >=, <, <=
+//   - "~1.2.3"               -> same minor line (>=1.2.3, <1.3.0)
+//   - "^1.2.3"               -> same major line (>=1.2.3, <2.0.0), or minor line for 0.x versions
+//   - ">=1.2.0, <2.0.0"      -> conditions separated by commas or spaces must all match
+//   - "^1.0.0 || ^2.0.0"     -> alternatives separated by || where any may match
+//   - "*"                    -> matches every version
+type Constraint struct {
+	raw    string
+	groups [][]condition
+}
+
+// condition is a single comparison within a constraint
+type condition func(info *Info) bool
+
+// ParseConstraint parses a version range expression, see Constraint for the supported syntax
+func ParseConstraint(constraint string) (*Constraint, error) {
+	raw := strings.TrimSpace(constraint)
+	if raw == "" {
+		return nil, fmt.Errorf("constraint cannot be empty")
+	}
+
+	c := &Constraint{raw: raw}
+	for _, alternative := range strings.Split(raw, "||") {
+		terms := strings.FieldsFunc(alternative, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		terms = joinDetachedOperators(terms)
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("invalid constraint: %s (empty alternative)", raw)
+		}
+
+		var group []condition
+		for _, term := range terms {
+			cond, err := parseCondition(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint: %s (%v)", raw, err)
+			}
+			group = append(group, cond)
+		}
+		c.groups = append(c.groups, group)
+	}
+
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics if the constraint cannot be parsed
+func MustParseConstraint(constraint string) *Constraint {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check returns true if the version satisfies the constraint
+func (c *Constraint) Check(info *Info) bool {
+	if c == nil || info == nil {
+		return false
+	}
+
+	for _, group := range c.groups {
+		matched := true
+		for _, cond := range group {
+			if !cond(info) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was written
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Satisfies parses the constraint and reports whether this version matches it
+func (i *Info) Satisfies(constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(i), nil
+}
+
+// joinDetachedOperators merges operators written apart from their version (">= 1.2.0") back together
+func joinDetachedOperators(terms []string) []string {
+	var joined []string
+	for idx := 0; idx < len(terms); idx++ {
+		term := terms[idx]
+		if strings.Trim(term, "=<>!~^") == "" && term != "*" && idx+1 < len(terms) {
+			term += terms[idx+1]
+			idx++
+		}
+		joined = append(joined, term)
+	}
+	return joined
+}
+
+// parseCondition parses a single operator and version pair
+func parseCondition(term string) (condition, error) {
+	if term == "*" || strings.EqualFold(term, "x") {
+		return func(*Info) bool { return true }, nil
+	}
+
+	operator := ""
+	for _, op := range []string{">=", "<=", "==", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, op) {
+			operator = op
+			break
+		}
+	}
+
+	base, err := Parse(strings.TrimPrefix(term, operator))
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "", "=", "==":
+		return func(info *Info) bool { return Compare(info, base) == 0 }, nil
+	case "!=":
+		return func(info *Info) bool { return Compare(info, base) != 0 }, nil
+	case ">":
+		return func(info *Info) bool { return Compare(info, base) > 0 }, nil
+	case ">=":
+		return func(info *Info) bool { return Compare(info, base) >= 0 }, nil
+	case "<":
+		return func(info *Info) bool { return Compare(info, base) < 0 }, nil
+	case "<=":
+		return func(info *Info) bool { return Compare(info, base) <= 0 }, nil
+	case "~":
+		upper := &Info{Major: base.Major, Minor: base.Minor + 1}
+		return func(info *Info) bool {
+			return Compare(info, base) >= 0 && compareCore(info, upper) < 0
+		}, nil
+	default: // "^"
+		upper := &Info{Major: base.Major + 1}
+		switch {
+		case base.Major == 0 && base.Minor == 0:
+			upper = &Info{Patch: base.Patch + 1}
+		case base.Major == 0:
+			upper = &Info{Minor: base.Minor + 1}
+		}
+		return func(info *Info) bool {
+			return Compare(info, base) >= 0 && compareCore(info, upper) < 0
+		}, nil
+	}
+}
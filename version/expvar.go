@@ -0,0 +1,87 @@
+package version
+
+import (
+	"expvar"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// expvarMu serializes PublishExpvar so the duplicate check and expvar.Publish cannot race
+var expvarMu sync.Mutex
+
+// expvarInfo is the JSON document published by PublishExpvar
+type expvarInfo struct {
+	Version    string          `json:"version"`
+	Major      int             `json:"major"`
+	Minor      int             `json:"minor"`
+	Patch      int             `json:"patch"`
+	Hash       string          `json:"hash,omitempty"`
+	Suffix     string          `json:"suffix,omitempty"`
+	Author     string          `json:"author,omitempty"`
+	Company    string          `json:"company,omitempty"`
+	Copyright  string          `json:"copyright,omitempty"`
+	Repo       string          `json:"repo,omitempty"`
+	Branch     string          `json:"branch,omitempty"`
+	BuildTime  string          `json:"build_time,omitempty"`
+	Metadata   []MetadataField `json:"metadata,omitempty"`
+	GoVersion  string          `json:"go_version"`
+	GOOS       string          `json:"goos"`
+	GOARCH     string          `json:"goarch"`
+	Compiler   string          `json:"compiler"`
+	NumCPU     int             `json:"num_cpu"`
+	GOMAXPROCS int             `json:"gomaxprocs"`
+}
+
+// PublishExpvar registers info and runtime details as an expvar.Var under name, so they are served
+// by /debug/vars next to memstats. Unlike expvar.Publish it returns an error instead of panicking
+// when the name is already registered.
+func PublishExpvar(name string, info *Info) error {
+	if name == "" {
+		return fmt.Errorf("expvar name cannot be empty")
+	}
+	if info == nil {
+		return fmt.Errorf("version info cannot be nil")
+	}
+
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q is already published", name)
+	}
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return newExpvarInfo(info)
+	}))
+	return nil
+}
+
+// newExpvarInfo snapshots info and the runtime details
+func newExpvarInfo(info *Info) expvarInfo {
+	ev := expvarInfo{
+		Version:    formatVersionString(info.Major, info.Minor, info.Patch, info.Hash, info.Suffix),
+		Major:      info.Major,
+		Minor:      info.Minor,
+		Patch:      info.Patch,
+		Hash:       info.Hash,
+		Suffix:     info.Suffix,
+		Author:     info.Author,
+		Company:    info.Company,
+		Copyright:  info.Copyright,
+		Repo:       info.Repo,
+		Branch:     info.Branch,
+		Metadata:   visibleMetadata(info.Metadata),
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		Compiler:   runtime.Compiler,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+	if !info.BuildTime.IsZero() {
+		ev.BuildTime = info.BuildTime.UTC().Format(time.RFC3339)
+	}
+	return ev
+}
@@ -0,0 +1,84 @@
+package version
+
+import (
+	"encoding/json"
+	"expvar"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	info := &Info{
+		Major:     1,
+		Minor:     4,
+		Patch:     2,
+		Hash:      "ABC123",
+		Suffix:    "rc1",
+		Company:   "Acme Corp",
+		BuildTime: time.Date(2025, 6, 1, 8, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+
+	if err := PublishExpvar("test_version_publish", info); err != nil {
+		t.Fatalf("PublishExpvar() unexpected error: %v", err)
+	}
+
+	v := expvar.Get("test_version_publish")
+	if v == nil {
+		t.Fatalf("expvar not registered")
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("expvar value is not JSON: %v (%s)", err, v.String())
+	}
+
+	want := map[string]any{
+		"version":    "1.4.2:ABC123-rc1",
+		"major":      float64(1),
+		"hash":       "ABC123",
+		"suffix":     "rc1",
+		"company":    "Acme Corp",
+		"build_time": "2025-06-01T06:30:00Z",
+		"go_version": runtime.Version(),
+		"goos":       runtime.GOOS,
+		"goarch":     runtime.GOARCH,
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+	if _, ok := got["author"]; ok {
+		t.Errorf("empty author should be omitted: %v", got)
+	}
+
+	// The published value follows later changes to info
+	info.Patch = 3
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("expvar value is not JSON: %v", err)
+	}
+	if got["patch"] != float64(3) {
+		t.Errorf("patch = %v, want 3 after update", got["patch"])
+	}
+}
+
+func TestPublishExpvarErrors(t *testing.T) {
+	info := &Info{Major: 1}
+
+	if err := PublishExpvar("test_version_duplicate", info); err != nil {
+		t.Fatalf("PublishExpvar() unexpected error: %v", err)
+	}
+	if err := PublishExpvar("test_version_duplicate", info); err == nil {
+		t.Errorf("PublishExpvar() with duplicate name expected error but got none")
+	}
+	if err := PublishExpvar("memstats", info); err == nil {
+		t.Errorf("PublishExpvar() with built-in name expected error but got none")
+	}
+	if err := PublishExpvar("", info); err == nil {
+		t.Errorf("PublishExpvar() with empty name expected error but got none")
+	}
+	if err := PublishExpvar("test_version_nil", nil); err == nil {
+		t.Errorf("PublishExpvar() with nil info expected error but got none")
+	}
+}
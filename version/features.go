@@ -0,0 +1,228 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultFeatureEnvPrefix is prepended to the feature name to build its override variable, e.g. FEATURE_NEW_SYNC
+const DefaultFeatureEnvPrefix = "FEATURE_"
+
+// FeatureRule describes the builds a feature is enabled on, every non-empty condition must match
+type FeatureRule struct {
+	// Constraint is a version range such as ">=2.1.0" (empty matches every version)
+	Constraint string
+	// Stabilities lists the channels the feature is enabled on, such as dev and canary (empty matches every channel)
+	Stabilities []Stability
+	// Dirty, when set, requires the build to come (true) or not come (false) from a dirty working tree
+	Dirty *bool
+	// Hashes is an allow-list of commit hashes or hash prefixes of at least 4 characters (empty matches every build)
+	Hashes []string
+}
+
+// feature is a registered rule with its parsed constraint
+type feature struct {
+	rule       FeatureRule
+	constraint *Constraint
+}
+
+// Features is a registry of version-gated features evaluated against the running build
+// Each feature can be forced on or off with an environment variable named after it,
+// e.g. FEATURE_NEW_SYNC=false for "new-sync".
+type Features struct {
+	// EnvPrefix is the prefix of the override variables (defaults to DefaultFeatureEnvPrefix)
+	EnvPrefix string
+	// LookupEnv reads the override variables (defaults to os.LookupEnv), tests can replace it
+	LookupEnv func(key string) (string, bool)
+
+	info     *Info
+	mu       sync.RWMutex
+	features map[string]*feature
+}
+
+// NewFeatures returns an empty registry evaluating rules against info
+func NewFeatures(info *Info) *Features {
+	return &Features{info: info, features: map[string]*feature{}}
+}
+
+// Register adds a feature, returning an error for an empty or duplicate name, an invalid constraint
+// or stability, or a hash shorter than 4 characters
+func (f *Features) Register(name string, rule FeatureRule) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("feature name cannot be empty")
+	}
+
+	entry := &feature{rule: rule}
+	if rule.Constraint != "" {
+		constraint, err := ParseConstraint(rule.Constraint)
+		if err != nil {
+			return fmt.Errorf("feature %s: %w", name, err)
+		}
+		entry.constraint = constraint
+	}
+	for _, stability := range rule.Stabilities {
+		if _, err := ParseStability(string(stability)); err != nil {
+			return fmt.Errorf("feature %s: %w", name, err)
+		}
+	}
+	for _, hash := range rule.Hashes {
+		if len(strings.TrimSpace(hash)) < minHashPrefix {
+			return fmt.Errorf("feature %s: hash %q is shorter than %d characters", name, hash, minHashPrefix)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.features[name]; exists {
+		return fmt.Errorf("feature %s is already registered", name)
+	}
+	f.features[name] = entry
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func (f *Features) MustRegister(name string, rule FeatureRule) {
+	if err := f.Register(name, rule); err != nil {
+		panic(err)
+	}
+}
+
+// Enabled returns true when the feature is forced on by its environment variable or its rule matches
+// the running build; unknown features are disabled.
+func (f *Features) Enabled(name string) bool {
+	f.mu.RLock()
+	entry, ok := f.features[name]
+	f.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	if enabled, ok := f.override(name); ok {
+		return enabled
+	}
+	return entry.matches(f.info)
+}
+
+// Active returns the names of the enabled features, sorted
+func (f *Features) Active() []string {
+	f.mu.RLock()
+	names := make([]string, 0, len(f.features))
+	for name := range f.features {
+		names = append(names, name)
+	}
+	f.mu.RUnlock()
+
+	sort.Strings(names)
+	active := names[:0]
+	for _, name := range names {
+		if f.Enabled(name) {
+			active = append(active, name)
+		}
+	}
+	return active
+}
+
+// Notice returns a banner line such as "Features: debug-endpoints, new-sync", or "" when none is active
+func (f *Features) Notice() string {
+	active := f.Active()
+	if len(active) == 0 {
+		return ""
+	}
+	return "Features: " + strings.Join(active, ", ")
+}
+
+// EnvVar returns the override variable of a feature, e.g. FEATURE_NEW_SYNC for "new-sync"
+func (f *Features) EnvVar(name string) string {
+	prefix := f.EnvPrefix
+	if prefix == "" {
+		prefix = DefaultFeatureEnvPrefix
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// override reads the environment variable of a feature, ignoring values that are not booleans
+func (f *Features) override(name string) (bool, bool) {
+	lookup := f.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	value, ok := lookup(f.EnvVar(name))
+	if !ok {
+		return false, false
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "yes", "enabled":
+		return true, true
+	case "off", "no", "disabled":
+		return false, true
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, false
+	}
+	return enabled, true
+}
+
+// matches evaluates the rule against info
+func (e *feature) matches(info *Info) bool {
+	if info == nil {
+		return false
+	}
+	if e.constraint != nil && !e.constraint.Check(info) {
+		return false
+	}
+	if len(e.rule.Stabilities) > 0 && !containsStability(e.rule.Stabilities, info.Stability()) {
+		return false
+	}
+	if e.rule.Dirty != nil && *e.rule.Dirty != info.Dirty {
+		return false
+	}
+	if len(e.rule.Hashes) > 0 && !hashAllowed(e.rule.Hashes, info.Hash) {
+		return false
+	}
+	return true
+}
+
+// containsStability reports whether stabilities holds s, rc suffixes such as rc2 matching rc
+func containsStability(stabilities []Stability, s Stability) bool {
+	for _, candidate := range stabilities {
+		if parsed, err := ParseStability(string(candidate)); err == nil && parsed == s {
+			return true
+		}
+	}
+	return false
+}
+
+// minHashPrefix is the shortest allow-list entry, the minimum length of an abbreviated git hash
+const minHashPrefix = 4
+
+// hashAllowed reports whether hash starts with an entry of the allow-list
+// A build hash shorter than the entry does not match, so that "d" cannot unlock "deadbeef".
+func hashAllowed(allowed []string, hash string) bool {
+	hash = strings.ToLower(hash)
+	if hash == "" {
+		return false
+	}
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if len(entry) >= minHashPrefix && strings.HasPrefix(hash, entry) {
+			return true
+		}
+	}
+	return false
+}
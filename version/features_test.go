@@ -0,0 +1,130 @@
+package version
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestFeatures registers the features used by the tests with a fake environment
+func newTestFeatures(t *testing.T, info *Info, env map[string]string) *Features {
+	t.Helper()
+	dirty := true
+	features := NewFeatures(info)
+	features.LookupEnv = func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	features.MustRegister("new-sync", FeatureRule{Constraint: ">=2.1.0"})
+	features.MustRegister("debug-endpoints", FeatureRule{Stabilities: []Stability{StabilityDev, StabilityCanary}})
+	features.MustRegister("dirty-warning", FeatureRule{Dirty: &dirty})
+	features.MustRegister("hotfix", FeatureRule{Hashes: []string{"4F00ABC", "deadbeef"}})
+	features.MustRegister("rc-telemetry", FeatureRule{Constraint: "^2.0.0", Stabilities: []Stability{"rc"}})
+	return features
+}
+
+func TestFeaturesActive(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		dirty   bool
+		env     map[string]string
+		want    []string
+	}{
+		{name: "old stable release", version: "2.0.0", want: []string{}},
+		{name: "version range", version: "2.1.0", want: []string{"new-sync"}},
+		{name: "dev channel", version: "1.9.0-dev", want: []string{"debug-endpoints"}},
+		{name: "dirty tree", version: "2.0.0", dirty: true, want: []string{"dirty-warning"}},
+		{name: "hash allow-list", version: "2.0.0:4f00abc123", want: []string{"hotfix"}},
+		{name: "short hash does not match", version: "2.0.0:d", want: []string{}},
+		{name: "hash prefix of an entry does not match", version: "2.0.0:4f00", want: []string{}},
+		{name: "rc channel and range", version: "2.2.0-rc2", want: []string{"new-sync", "rc-telemetry"}},
+		{
+			name:    "environment overrides",
+			version: "2.1.0",
+			env:     map[string]string{"FEATURE_NEW_SYNC": "false", "FEATURE_DEBUG_ENDPOINTS": "on", "FEATURE_HOTFIX": "maybe"},
+			want:    []string{"debug-endpoints"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := mustParse(t, tt.version)
+			info.Dirty = tt.dirty
+			features := newTestFeatures(t, info, tt.env)
+
+			if got := features.Active(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Active() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeaturesEnabledUnknown(t *testing.T) {
+	features := newTestFeatures(t, mustParse(t, "3.0.0"), map[string]string{"FEATURE_UNKNOWN": "true"})
+	if features.Enabled("unknown") {
+		t.Errorf("Enabled(unknown) = true, want false for an unregistered feature")
+	}
+	if !features.Enabled("new-sync") {
+		t.Errorf("Enabled(new-sync) = false, want true")
+	}
+}
+
+func TestFeaturesRegisterInvalid(t *testing.T) {
+	features := NewFeatures(mustParse(t, "1.0.0"))
+
+	tests := []struct {
+		name    string
+		feature string
+		rule    FeatureRule
+	}{
+		{name: "empty name", feature: " "},
+		{name: "invalid constraint", feature: "a", rule: FeatureRule{Constraint: ">>1"}},
+		{name: "invalid stability", feature: "b", rule: FeatureRule{Stabilities: []Stability{"nightly"}}},
+		{name: "short hash", feature: "d", rule: FeatureRule{Hashes: []string{"dea"}}},
+	}
+	for _, tt := range tests {
+		if err := features.Register(tt.feature, tt.rule); err == nil {
+			t.Errorf("%s: Register() expected error but got none", tt.name)
+		}
+	}
+
+	features.MustRegister("c", FeatureRule{})
+	if err := features.Register("c", FeatureRule{}); err == nil {
+		t.Errorf("Register() with duplicate name expected error but got none")
+	}
+}
+
+func TestFeaturesEnvVar(t *testing.T) {
+	features := NewFeatures(nil)
+	if got := features.EnvVar("new-sync.v2"); got != "FEATURE_NEW_SYNC_V2" {
+		t.Errorf("EnvVar() = %q, want %q", got, "FEATURE_NEW_SYNC_V2")
+	}
+	features.EnvPrefix = "MYAPP_"
+	if got := features.EnvVar("beta"); got != "MYAPP_BETA" {
+		t.Errorf("EnvVar() = %q, want %q", got, "MYAPP_BETA")
+	}
+}
+
+func TestHandlerFeatures(t *testing.T) {
+	info := mustParse(t, "2.2.0-dev")
+	features := newTestFeatures(t, info, nil)
+	handler := HandlerWithOptions(info, HandlerOptions{AppName: "App", Features: features})
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: FormatJSON, want: `{"version":"2.2.0","build_type":"dev","features":["debug-endpoints","new-sync"]}` + "\n"},
+		{format: FormatText, want: "Features: debug-endpoints, new-sync\n"},
+		{format: FormatBanner, want: "Features: debug-endpoints, new-sync"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/version?format="+tt.format, nil))
+		if body := rec.Body.String(); !strings.Contains(body, tt.want) {
+			t.Errorf("format %s body = %q, want it to contain %q", tt.format, body, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package version
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FlagOptions configures the flag installed by RegisterFlagWithOptions
+type FlagOptions struct {
+	// Name is the flag name (defaults to "version", accepted as -version and --version)
+	Name string
+	// ProgramName is printed in the text output and banner (defaults to the flag set name)
+	ProgramName string
+	// Output receives the version output (defaults to os.Stdout)
+	Output io.Writer
+	// Exit is called with 0 after printing (defaults to os.Exit), tests can replace it
+	Exit func(code int)
+	// Banner configures the banner format (defaults to the settings used by Banner)
+	Banner *BannerOptions
+}
+
+// RegisterFlag installs a -version/--version flag on fs (flag.CommandLine when nil) that prints the
+// version information and exits. The flag takes an optional format: -version, -version=short,
+// -version=json or -version=banner; the default text output follows the GNU --version conventions.
+func RegisterFlag(fs *flag.FlagSet, info *Info) {
+	RegisterFlagWithOptions(fs, info, FlagOptions{})
+}
+
+// RegisterFlagWithOptions installs a version flag on fs using custom options
+func RegisterFlagWithOptions(fs *flag.FlagSet, info *Info, opts FlagOptions) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	if opts.Name == "" {
+		opts.Name = "version"
+	}
+	if opts.ProgramName == "" {
+		opts.ProgramName = filepath.Base(fs.Name())
+	}
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+	if opts.Exit == nil {
+		opts.Exit = os.Exit
+	}
+
+	fs.Var(&versionFlag{info: info, opts: opts}, opts.Name, "print version information and exit (format: text, short, json or banner)")
+}
+
+// versionFlag is a boolean-style flag.Value that prints the version as soon as it is set
+type versionFlag struct {
+	info *Info
+	opts FlagOptions
+}
+
+// String implements flag.Value
+func (f *versionFlag) String() string {
+	return ""
+}
+
+// IsBoolFlag allows the flag to be used without a value
+func (f *versionFlag) IsBoolFlag() bool {
+	return true
+}
+
+// Set implements flag.Value, printing the version in the requested format and exiting
+func (f *versionFlag) Set(value string) error {
+	format := strings.ToLower(strings.TrimSpace(value))
+	switch format {
+	case "false":
+		return nil
+	case "true", "":
+		format = FormatText
+	}
+
+	output, err := f.render(format)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(f.opts.Output, output); err != nil {
+		return err
+	}
+	f.opts.Exit(0)
+	return nil
+}
+
+// render returns the version output for the given format
+func (f *versionFlag) render(format string) (string, error) {
+	switch format {
+	case FormatText:
+		return gnuVersionText(f.opts.ProgramName, f.info), nil
+	case "short":
+		return f.info.Short(), nil
+	case FormatJSON:
+		return f.info.JSON()
+	case FormatBanner:
+		opts := BannerOptions{AutoWidth: true, FixedWidth: defaultBoxWidth, FontStyle: FontStyleSlant}
+		if f.opts.Banner != nil {
+			opts = *f.opts.Banner
+		}
+		return BannerWithOptions(f.opts.ProgramName, f.info, opts), nil
+	default:
+		return "", fmt.Errorf("invalid format: %s (expected: text, short, json or banner)", format)
+	}
+}
+
+// gnuVersionText formats the version following the GNU --version conventions
+// Example:
+//
+//	myapp 1.2.3 (ABC123) [BETA]
+//	Copyright (C) 2025 Acme Corp
+//
+//	Written by Jane Doe.
+func gnuVersionText(program string, info *Info) string {
+	lines := []string{strings.TrimSpace(program + " " + strings.TrimPrefix(info.Text(), "v"))}
+
+	if info.Copyright != "" {
+		lines = append(lines, gnuCopyright(info.Copyright))
+	}
+	if info.Author != "" {
+		lines = append(lines, "", fmt.Sprintf("Written by %s.", strings.TrimSuffix(info.Author, ".")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// gnuCopyright normalizes a copyright notice to the "Copyright (C) <year> <holder>" form
+func gnuCopyright(copyright string) string {
+	notice := strings.TrimSpace(copyright)
+	if strings.HasPrefix(strings.ToLower(notice), "copyright") {
+		return notice
+	}
+	for _, symbol := range []string{"©", "(C)", "(c)"} {
+		notice = strings.TrimSpace(strings.TrimPrefix(notice, symbol))
+	}
+	return "Copyright (C) " + notice
+}
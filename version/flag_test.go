@@ -0,0 +1,131 @@
+package version
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFlag(t *testing.T) {
+	info := &Info{
+		Major:     1,
+		Minor:     2,
+		Patch:     3,
+		Hash:      "ABC123",
+		Suffix:    "beta",
+		Author:    "Jane Doe",
+		Copyright: "2025 Acme Corp",
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantExit   bool
+		wantErr    bool
+	}{
+		{
+			name:       "single dash",
+			args:       []string{"-version"},
+			wantOutput: "myapp 1.2.3 (ABC123) [BETA]\nCopyright (C) 2025 Acme Corp\n\nWritten by Jane Doe.\n",
+			wantExit:   true,
+		},
+		{
+			name:       "double dash",
+			args:       []string{"--version"},
+			wantOutput: "myapp 1.2.3 (ABC123) [BETA]\nCopyright (C) 2025 Acme Corp\n\nWritten by Jane Doe.\n",
+			wantExit:   true,
+		},
+		{
+			name:       "short",
+			args:       []string{"--version=short"},
+			wantOutput: "v1.2.3-beta\n",
+			wantExit:   true,
+		},
+		{
+			name:       "json",
+			args:       []string{"-version=json"},
+			wantOutput: `{"version":"1.2.3","hash":"ABC123","build_type":"beta"}` + "\n",
+			wantExit:   true,
+		},
+		{
+			name:       "banner",
+			args:       []string{"-version=banner"},
+			wantOutput: "myapp\n\nv1.2.3 ABC123 [BETA]\n",
+			wantExit:   true,
+		},
+		{
+			name: "explicit false",
+			args: []string{"-version=false"},
+		},
+		{
+			name: "not set",
+			args: []string{"-verbose"},
+		},
+		{
+			name:    "invalid format",
+			args:    []string{"-version=yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			exitCode := -1
+
+			fs := flag.NewFlagSet("myapp", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Bool("verbose", false, "verbose output")
+			RegisterFlagWithOptions(fs, info, FlagOptions{
+				Output: &out,
+				Exit:   func(code int) { exitCode = code },
+			})
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantExit != (exitCode == 0) {
+				t.Errorf("exit code = %d, want exit %t", exitCode, tt.wantExit)
+			}
+			if !strings.HasPrefix(out.String(), tt.wantOutput) || (tt.wantOutput == "" && out.Len() > 0) {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestGNUVersionText(t *testing.T) {
+	tests := []struct {
+		name string
+		info *Info
+		want string
+	}{
+		{
+			name: "version only",
+			info: &Info{Major: 0, Minor: 1, Patch: 0},
+			want: "tool 0.1.0",
+		},
+		{
+			name: "copyright symbol",
+			info: &Info{Major: 1, Copyright: "© 2024 Example Ltd"},
+			want: "tool 1.0.0\nCopyright (C) 2024 Example Ltd",
+		},
+		{
+			name: "copyright already prefixed",
+			info: &Info{Major: 1, Copyright: "Copyright 2024 Example Ltd. All rights reserved."},
+			want: "tool 1.0.0\nCopyright 2024 Example Ltd. All rights reserved.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gnuVersionText("tool", tt.info); got != tt.want {
+				t.Errorf("gnuVersionText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
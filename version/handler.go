@@ -0,0 +1,204 @@
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormatJSON - compact JSON from Info.JSON
+	FormatJSON = "json"
+	// FormatPretty - indented JSON from Info.JSONPretty
+	FormatPretty = "pretty"
+	// FormatText - plain text from Info.Text
+	FormatText = "text"
+	// FormatBanner - the banner from BannerWithOptions
+	FormatBanner = "banner"
+
+	defaultCacheControl = "no-cache"
+	jsonContentType     = "application/json"
+	textContentType     = "text/plain; charset=utf-8"
+)
+
+// HandlerOptions configures the handler returned by HandlerWithOptions
+type HandlerOptions struct {
+	// AppName is the application name rendered by the banner format
+	AppName string
+	// Banner configures the banner format (defaults to the settings used by Banner)
+	Banner *BannerOptions
+	// CacheControl sets the Cache-Control header (defaults to "no-cache", so clients revalidate with the ETag)
+	CacheControl string
+	// Features, when set, adds the active features to every format
+	Features *Features
+}
+
+// Handler returns an http.Handler serving the version information, typically mounted on /version
+// The format is selected with the ?format= parameter (json, pretty, text or banner) or negotiated
+// from the Accept header (application/json or text/plain), JSON being the default.
+func Handler(info *Info) http.Handler {
+	return HandlerWithOptions(info, HandlerOptions{})
+}
+
+// HandlerWithOptions returns an http.Handler serving the version information using custom options
+func HandlerWithOptions(info *Info, opts HandlerOptions) http.Handler {
+	if opts.CacheControl == "" {
+		opts.CacheControl = defaultCacheControl
+	}
+	return &versionHandler{info: info, opts: opts}
+}
+
+// versionHandler serves an Info in the negotiated format
+type versionHandler struct {
+	info *Info
+	opts HandlerOptions
+}
+
+// ServeHTTP implements http.Handler
+func (h *versionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = negotiateFormat(r.Header.Get("Accept"))
+		if format == "" {
+			http.Error(w, "not acceptable: supported media types are application/json and text/plain", http.StatusNotAcceptable)
+			return
+		}
+	}
+
+	body, contentType, err := h.render(format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	etag := computeETag(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", h.opts.CacheControl)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// render returns the response body and content type for the given format
+func (h *versionHandler) render(format string) ([]byte, string, error) {
+	switch strings.ToLower(format) {
+	case FormatJSON, FormatPretty:
+		vj := h.info.ToJSON()
+		if h.opts.Features != nil {
+			vj.Features = h.opts.Features.Active()
+		}
+		var out []byte
+		var err error
+		if strings.EqualFold(format, FormatPretty) {
+			out, err = json.MarshalIndent(vj, "", "  ")
+		} else {
+			out, err = json.Marshal(vj)
+		}
+		return append(out, '\n'), jsonContentType, err
+	case FormatText:
+		text := h.info.Text()
+		if notice := h.featuresNotice(); notice != "" {
+			text += "\n" + notice
+		}
+		return []byte(text + "\n"), textContentType, nil
+	case FormatBanner:
+		opts := BannerOptions{AutoWidth: true, FixedWidth: defaultBoxWidth, FontStyle: FontStyleSlant}
+		if h.opts.Banner != nil {
+			opts = *h.opts.Banner
+		}
+		if notice := h.featuresNotice(); notice != "" {
+			opts.Notices = append(append([]string(nil), opts.Notices...), notice)
+		}
+		return []byte(BannerWithOptions(h.opts.AppName, h.info, opts) + "\n"), textContentType, nil
+	default:
+		return nil, "", fmt.Errorf("invalid format: %s (expected: json, pretty, text or banner)", format)
+	}
+}
+
+// featuresNotice returns the active features line, or "" when no registry is configured
+func (h *versionHandler) featuresNotice() string {
+	if h.opts.Features == nil {
+		return ""
+	}
+	return h.opts.Features.Notice()
+}
+
+// negotiateFormat picks json or text from an Accept header, returning "" when neither is acceptable
+func negotiateFormat(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return FormatJSON
+	}
+
+	best := ""
+	bestQuality := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		format := ""
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			format = FormatJSON
+		case "text/plain", "text/*":
+			format = FormatText
+		}
+
+		if format != "" && quality > bestQuality {
+			best = format
+			bestQuality = quality
+		}
+	}
+	return best
+}
+
+// computeETag returns a strong entity tag derived from the response body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches the entity tag (weak comparison)
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
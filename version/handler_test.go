@@ -0,0 +1,165 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerFormats(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "beta", Author: "Jane"}
+	handler := HandlerWithOptions(info, HandlerOptions{AppName: "MyApp"})
+
+	tests := []struct {
+		name            string
+		target          string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "default is json",
+			target:          "/version",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"version":"1.2.3","hash":"ABC123","build_type":"beta"}` + "\n",
+		},
+		{
+			name:            "accept text",
+			target:          "/version",
+			accept:          "text/plain",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "v1.2.3 (ABC123) [BETA]\n",
+		},
+		{
+			name:            "accept prefers higher quality",
+			target:          "/version",
+			accept:          "application/json;q=0.5, text/plain;q=0.9",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "v1.2.3 (ABC123) [BETA]\n",
+		},
+		{
+			name:            "accept wildcard",
+			target:          "/version",
+			accept:          "*/*",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "accept unsupported",
+			target:     "/version",
+			accept:     "image/png",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:            "format parameter wins over accept",
+			target:          "/version?format=pretty",
+			accept:          "text/plain",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        "{\n  \"version\": \"1.2.3\",\n  \"hash\": \"ABC123\",\n  \"build_type\": \"beta\"\n}\n",
+		},
+		{
+			name:            "banner format",
+			target:          "/version?format=banner",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "MyApp\n\nv1.2.3 ABC123 [BETA]\n\nAuthor:    Jane\n\n",
+		},
+		{
+			name:       "invalid format",
+			target:     "/version?format=xml",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantContentType != "" && rec.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if rec.Header().Get("ETag") == "" {
+					t.Errorf("ETag header missing")
+				}
+				if rec.Header().Get("Cache-Control") != "no-cache" {
+					t.Errorf("Cache-Control = %q, want %q", rec.Header().Get("Cache-Control"), "no-cache")
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerConditionalAndMethods(t *testing.T) {
+	info := &Info{Major: 0, Minor: 1, Patch: 0}
+	server := httptest.NewServer(HandlerWithOptions(info, HandlerOptions{CacheControl: "public, max-age=60"}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	var got VersionJSON
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if got.Version != "0.1.0" {
+		t.Errorf("Version = %q, want %q", got.Version, "0.1.0")
+	}
+	if resp.Header.Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("Cache-Control = %q, want %q", resp.Header.Get("Cache-Control"), "public, max-age=60")
+	}
+	etag := resp.Header.Get("ETag")
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("If-None-Match", "W/"+etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("conditional GET error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("conditional GET status = %d, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+
+	resp, err = http.Head(server.URL + "?format=text")
+	if err != nil {
+		t.Fatalf("HEAD error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength != int64(len("v0.1.0\n")) {
+		t.Errorf("HEAD status = %d, Content-Length = %d, want 200 and %d", resp.StatusCode, resp.ContentLength, len("v0.1.0\n"))
+	}
+	if resp.Header.Get("ETag") == etag {
+		t.Errorf("text and json representations share ETag %q", etag)
+	}
+
+	resp, err = http.Post(server.URL, "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST status = %d, Allow = %q, want 405 and %q", resp.StatusCode, resp.Header.Get("Allow"), "GET, HEAD")
+	}
+}
@@ -0,0 +1,450 @@
+package version
+
+import "strings"
+
+// BannerSection identifies the part of the banner a line belongs to
+type BannerSection string
+
+const (
+	// SectionBorder - the top and bottom of the box and the dividers
+	SectionBorder BannerSection = "border"
+	// SectionBlank - spacing between the sections and the margins
+	SectionBlank BannerSection = "blank"
+	// SectionTitle - the application name or its ASCII art
+	SectionTitle BannerSection = "title"
+	// SectionVersion - the version line
+	SectionVersion BannerSection = "version"
+	// SectionNotice - the notice lines
+	SectionNotice BannerSection = "notice"
+	// SectionMetadata - the "Label: value" lines
+	SectionMetadata BannerSection = "metadata"
+)
+
+// SpanRole is the part of the theme a span is colored with
+type SpanRole string
+
+const (
+	// RolePlain - spacing and padding, never colored
+	RolePlain SpanRole = ""
+	// RoleBorder - box edges and dividers
+	RoleBorder SpanRole = "border"
+	// RoleTitle - the application name or its ASCII art
+	RoleTitle SpanRole = "title"
+	// RoleVersion - the version number and hash
+	RoleVersion SpanRole = "version"
+	// RoleSuffix - the suffix badge, colored per stability level
+	RoleSuffix SpanRole = "suffix"
+	// RoleLabel - the metadata labels
+	RoleLabel SpanRole = "label"
+	// RoleValue - the metadata values
+	RoleValue SpanRole = "value"
+	// RoleNotice - the notice text
+	RoleNotice SpanRole = "notice"
+)
+
+// BannerSpan is a run of text drawn in a single color
+type BannerSpan struct {
+	Text string
+	Role SpanRole
+}
+
+// BannerLine is a line of the banner made of spans
+type BannerLine struct {
+	Section BannerSection
+	Spans   []BannerSpan
+}
+
+// BannerLayout is a banner laid out as lines of spans, ready to be drawn by a BannerRenderer
+type BannerLayout struct {
+	// AppName and Info are the values the banner was laid out for
+	AppName string
+	Info    *Info
+	// Theme colors the spans by role (nil for no colors)
+	Theme *Theme
+	// Lines are the banner lines, including borders, blank lines and margins
+	Lines []BannerLine
+	// Notices and Metadata are the visible notices and metadata fields, for renderers that build their own layout
+	Notices  []string
+	Metadata []MetadataField
+}
+
+// Text returns the line without colors
+func (l BannerLine) Text() string {
+	var b strings.Builder
+	for _, span := range l.Spans {
+		b.WriteString(span.Text)
+	}
+	return b.String()
+}
+
+// Text returns the banner without colors
+func (l *BannerLayout) Text() string {
+	lines := make([]string, len(l.Lines))
+	for idx, line := range l.Lines {
+		lines[idx] = line.Text()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Color returns the theme color of role, the suffix color depending on the stability of Info.Suffix
+func (l *BannerLayout) Color(role SpanRole) Color {
+	theme := l.Theme
+	if theme == nil {
+		return ""
+	}
+	switch role {
+	case RoleBorder:
+		return theme.Border
+	case RoleTitle:
+		return theme.Title
+	case RoleVersion:
+		return theme.Version
+	case RoleSuffix:
+		if l.Info != nil {
+			return theme.suffixColor(l.Info.Suffix)
+		}
+	case RoleLabel:
+		return theme.Label
+	case RoleValue:
+		return theme.Value
+	case RoleNotice:
+		return theme.Notice
+	}
+	return ""
+}
+
+// newLine builds a line from spans, dropping empty ones
+func newLine(section BannerSection, spans ...BannerSpan) BannerLine {
+	line := BannerLine{Section: section}
+	for _, span := range spans {
+		if span.Text != "" {
+			line.Spans = append(line.Spans, span)
+		}
+	}
+	return line
+}
+
+// spaces returns a plain span of n spaces
+func spaces(n int) BannerSpan {
+	return BannerSpan{Text: strings.Repeat(" ", max(n, 0))}
+}
+
+// width returns the display width of the line
+func (l BannerLine) width() int {
+	return ansiWidth(l.Text())
+}
+
+// truncate cuts the line at width columns
+func (l BannerLine) truncate(width int) BannerLine {
+	truncated := BannerLine{Section: l.Section}
+	remaining := width
+	for _, span := range l.Spans {
+		if spanWidth := ansiWidth(span.Text); spanWidth > remaining {
+			span.Text = truncateANSI(span.Text, remaining)
+			remaining = 0
+		} else {
+			remaining -= spanWidth
+		}
+		if span.Text != "" {
+			truncated.Spans = append(truncated.Spans, span)
+		}
+		if remaining == 0 {
+			break
+		}
+	}
+	return truncated
+}
+
+// prepend returns the line with span in front
+func (l BannerLine) prepend(span BannerSpan) BannerLine {
+	return newLine(l.Section, append([]BannerSpan{span}, l.Spans...)...)
+}
+
+// align positions the line within width columns like alignText
+func (l BannerLine) align(width int, align Alignment) BannerLine {
+	lineWidth := l.width()
+	if lineWidth >= width || width <= 0 {
+		return l
+	}
+
+	switch align {
+	case AlignCenter:
+		left := (width - lineWidth) / 2
+		aligned := l.prepend(spaces(left))
+		return newLine(l.Section, append(aligned.Spans, spaces(width-lineWidth-left))...)
+	case AlignRight:
+		return l.prepend(spaces(width - lineWidth))
+	}
+	return l
+}
+
+// alignLines positions lines as a block within width columns like alignBlock
+func alignLines(lines []BannerLine, width int, align Alignment) []BannerLine {
+	if align != AlignCenter && align != AlignRight {
+		return lines
+	}
+
+	blockWidth := 0
+	for _, line := range lines {
+		blockWidth = max(blockWidth, visibleWidth(line.Text()))
+	}
+	offset := width - blockWidth
+	if align == AlignCenter {
+		offset /= 2
+	}
+
+	aligned := make([]BannerLine, len(lines))
+	for idx, line := range lines {
+		if offset > 0 {
+			line = line.prepend(spaces(offset))
+		}
+		aligned[idx] = line
+	}
+	return aligned
+}
+
+// lineTexts returns the text of every line
+func lineTexts(lines []BannerLine) []string {
+	texts := make([]string, len(lines))
+	for idx, line := range lines {
+		texts[idx] = line.Text()
+	}
+	return texts
+}
+
+// versionSpans returns the version line, the suffix badge in its own span
+func versionSpans(info *Info) BannerLine {
+	line := formatVersionLine(info)
+	if info.Suffix == "" {
+		return newLine(SectionVersion, BannerSpan{Text: line, Role: RoleVersion})
+	}
+	badge := "[" + strings.ToUpper(info.Suffix) + "]"
+	return newLine(SectionVersion,
+		BannerSpan{Text: strings.TrimSuffix(line, " "+badge), Role: RoleVersion},
+		spaces(1),
+		BannerSpan{Text: badge, Role: RoleSuffix},
+	)
+}
+
+// artMargin keeps extra room around width-constrained ASCII art, on top of the padding
+const artMargin = paddingLeft * 2
+
+// bannerFrame resolves whether the banner has a border, its style and the padding inside it
+func bannerFrame(opts BannerOptions) (showBorder bool, style BorderStyle, padding int) {
+	// Default ShowBorder based on AutoWidth if not explicitly set
+	showBorder = true
+	if opts.ShowBorder != nil {
+		showBorder = *opts.ShowBorder
+	} else if opts.AutoWidth {
+		showBorder = false // Default to no border for auto-width
+	}
+
+	style = BorderAsterisk
+	if opts.Border != nil {
+		style = *opts.Border
+		if style.IsNone() {
+			showBorder = false
+		}
+	}
+
+	padding = defaultPadding
+	if opts.Padding != nil {
+		padding = max(*opts.Padding, 0)
+	}
+	return showBorder, style, padding
+}
+
+// LayoutBanner lays out the banner BannerWithOptions draws, as lines of spans
+func LayoutBanner(appName string, info *Info, opts BannerOptions) *BannerLayout {
+	showBorder, style, padding := bannerFrame(opts)
+	overhead := style.overhead(padding)
+
+	var titleLines []string
+
+	// Generate title lines (ASCII art or simple text)
+	if opts.UseASCII {
+		switch {
+		case opts.AutoWidth:
+			// Generate without width constraint to get natural size, unless capped
+			available := 0
+			if opts.MaxWidth > 0 {
+				available = max(opts.MaxWidth-artMargin, 1)
+				if showBorder {
+					available = max(available-overhead, 1)
+				}
+			}
+			titleLines = GenerateASCIIArtWithStyle(appName, available, opts.FontStyle)
+		case opts.FixedWidth > 0:
+			available := opts.FixedWidth - overhead - artMargin
+			if available < 0 {
+				available = 0
+			}
+			// Generate with width constraint
+			titleLines = GenerateASCIIArtWithStyle(appName, available, opts.FontStyle)
+		default:
+			// No explicit width - let ASCII art determine width
+			titleLines = GenerateASCIIArtWithStyle(appName, 0, opts.FontStyle)
+		}
+	} else {
+		// Simple text title - split by newlines if multi-line
+		titleLines = splitManualTitleLines(appName)
+	}
+
+	if len(titleLines) == 0 {
+		titleLines = []string{""}
+	}
+
+	// Calculate the longest line in title for centering (trim trailing spaces)
+	maxTitleWidth := longestLineWidth(titleLines)
+	notices := nonEmptyLines(opts.Notices)
+
+	fields := info.MetadataFields(opts.Metadata...)
+	metadata := metadataLines(fields, 0, opts.Overflow)
+	contentWidth := maxContentWidth(titleLines, info, notices, lineTexts(metadata))
+
+	// Calculate box width
+	var boxWidth int
+	switch {
+	case opts.AutoWidth:
+		boxWidth = calculateAutoWidth(titleLines, info, notices, lineTexts(metadata), overhead)
+	case opts.FixedWidth > 0:
+		boxWidth = opts.FixedWidth
+	default:
+		boxWidth = contentWidth + overhead
+		if boxWidth < defaultBoxWidth {
+			boxWidth = defaultBoxWidth
+		}
+	}
+	if opts.MaxWidth > 0 {
+		boxWidth = min(boxWidth, opts.MaxWidth)
+		contentWidth = min(contentWidth, opts.MaxWidth)
+	}
+
+	innerWidth := boxWidth - overhead
+
+	// Fit metadata and notices to the box, or to MaxWidth without a border
+	layoutWidth := opts.MaxWidth
+	if showBorder {
+		layoutWidth = max(innerWidth, 1)
+	}
+	metadata = metadataLines(fields, layoutWidth, opts.Overflow)
+	var fittedNotices []BannerLine
+	for _, notice := range notices {
+		for _, text := range fitText(notice, layoutWidth, opts.Overflow) {
+			fittedNotices = append(fittedNotices, newLine(SectionNotice, BannerSpan{Text: text, Role: RoleNotice}))
+		}
+	}
+
+	border := func(text string) BannerLine {
+		return newLine(SectionBorder, BannerSpan{Text: text, Role: RoleBorder})
+	}
+	boxLine := func(content BannerLine) BannerLine {
+		// Calculate available width (excluding the edges and the padding on each side)
+		available := max(boxWidth-ansiWidth(style.Left)-ansiWidth(style.Right)-2*padding, 0)
+		content = content.truncate(available)
+		spans := []BannerSpan{{Text: style.Left, Role: RoleBorder}, spaces(padding)}
+		spans = append(spans, content.Spans...)
+		spans = append(spans, spaces(available-content.width()+padding), BannerSpan{Text: style.Right, Role: RoleBorder})
+		return newLine(content.Section, spans...)
+	}
+	blank := func() BannerLine {
+		if showBorder {
+			return boxLine(BannerLine{Section: SectionBlank})
+		}
+		return BannerLine{Section: SectionBlank}
+	}
+	divider := func() []BannerLine {
+		if !opts.Dividers {
+			return nil
+		}
+		if showBorder {
+			return []BannerLine{border(horizontalLine(style.DividerLeft, style.Divider, style.DividerRight, boxWidth)), blank()}
+		}
+		fill := style.Divider
+		if fill == "" {
+			fill = "-"
+		}
+		return []BannerLine{border(repeatToWidth(fill, contentWidth)), blank()}
+	}
+
+	var lines []BannerLine
+
+	// Top border
+	if showBorder {
+		lines = append(lines, border(horizontalLine(style.TopLeft, style.Top, style.TopRight, boxWidth)))
+		// Empty line after top border
+		lines = append(lines, blank())
+	}
+
+	// Add title lines (centered within box when border is shown, as written without one)
+	for _, text := range titleLines {
+		line := newLine(SectionTitle, BannerSpan{Text: text, Role: RoleTitle})
+		switch {
+		case showBorder:
+			lines = append(lines, boxLine(line.align(innerWidth, alignOr(opts.TitleAlign, AlignCenter))))
+		case opts.TitleAlign != "":
+			lines = append(lines, line.align(contentWidth, opts.TitleAlign))
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	// Empty line after title
+	lines = append(lines, blank())
+	lines = append(lines, divider()...)
+
+	// Version line and notices (centered based on longest title line without a border)
+	versionAlign := alignOr(opts.VersionAlign, AlignCenter)
+	versionWidth := innerWidth
+	if !showBorder {
+		versionWidth = maxTitleWidth
+		if opts.VersionAlign != "" {
+			versionWidth = contentWidth
+		}
+	}
+	for _, line := range append([]BannerLine{versionSpans(info)}, fittedNotices...) {
+		line = line.align(versionWidth, versionAlign)
+		if showBorder {
+			line = boxLine(line)
+		}
+		lines = append(lines, line)
+	}
+
+	// Empty line after version
+	lines = append(lines, blank())
+	hasMetadata := len(metadata) > 0
+	if hasMetadata {
+		lines = append(lines, divider()...)
+	}
+
+	// Metadata lines
+	metadataWidth := contentWidth
+	if showBorder {
+		metadataWidth = innerWidth
+	}
+	for _, line := range alignLines(metadata, metadataWidth, opts.MetadataAlign) {
+		if showBorder {
+			line = boxLine(line)
+		}
+		lines = append(lines, line)
+	}
+
+	// Empty line before bottom border (only if we have metadata)
+	if hasMetadata {
+		lines = append(lines, blank())
+	}
+
+	// Bottom border
+	if showBorder {
+		lines = append(lines, border(horizontalLine(style.BottomLeft, style.Bottom, style.BottomRight, boxWidth)))
+	}
+
+	return &BannerLayout{
+		AppName:  appName,
+		Info:     info,
+		Theme:    opts.Theme,
+		Lines:    opts.Margin.apply(lines),
+		Notices:  notices,
+		Metadata: fields,
+	}
+}
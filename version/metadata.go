@@ -0,0 +1,115 @@
+package version
+
+import "strings"
+
+// minMetadataLabelWidth keeps the value column where the built-in labels put it ("Copyright: ")
+const minMetadataLabelWidth = len("Copyright:")
+
+// MetadataField is a key/value line shown under the version, e.g. License: MIT
+type MetadataField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Hidden keeps the field out of banners and JSON, a hidden field also hides a built-in field with the same key
+	Hidden bool `json:"-"`
+}
+
+// SetMetadata sets the value of the field named key, appending it when missing
+// Keys are matched case-insensitively and the field is made visible again.
+func (i *Info) SetMetadata(key, value string) {
+	for idx := range i.Metadata {
+		if strings.EqualFold(i.Metadata[idx].Key, key) {
+			i.Metadata[idx].Value = value
+			i.Metadata[idx].Hidden = false
+			return
+		}
+	}
+	i.Metadata = append(i.Metadata, MetadataField{Key: key, Value: value})
+}
+
+// HideMetadata hides the field named key, including the built-in Author, Company, Copyright and Repo fields
+func (i *Info) HideMetadata(key string) {
+	for idx := range i.Metadata {
+		if strings.EqualFold(i.Metadata[idx].Key, key) {
+			i.Metadata[idx].Hidden = true
+			return
+		}
+	}
+	i.Metadata = append(i.Metadata, MetadataField{Key: key, Hidden: true})
+}
+
+// MetadataFields returns the visible metadata in display order: Author, Company, Copyright and Repo,
+// followed by Info.Metadata and extra. A later field with the same key as an earlier one replaces it
+// in place, so extra can override or hide any field. Empty values are skipped.
+func (i *Info) MetadataFields(extra ...MetadataField) []MetadataField {
+	fields := []MetadataField{
+		{Key: "Author", Value: i.Author},
+		{Key: "Company", Value: i.Company},
+		{Key: "Copyright", Value: i.Copyright},
+		{Key: "Repo", Value: i.Repo},
+	}
+	fields = mergeMetadata(fields, i.Metadata)
+	fields = mergeMetadata(fields, extra)
+	return visibleMetadata(fields)
+}
+
+// mergeMetadata applies overrides to fields, replacing fields with the same key and appending new ones
+func mergeMetadata(fields, overrides []MetadataField) []MetadataField {
+	for _, override := range overrides {
+		replaced := false
+		for idx := range fields {
+			if strings.EqualFold(fields[idx].Key, override.Key) {
+				fields[idx] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			fields = append(fields, override)
+		}
+	}
+	return fields
+}
+
+// visibleMetadata drops hidden fields and fields without a key or value
+func visibleMetadata(fields []MetadataField) []MetadataField {
+	var visible []MetadataField
+	for _, field := range fields {
+		if field.Hidden || strings.TrimSpace(field.Key) == "" || field.Value == "" {
+			continue
+		}
+		visible = append(visible, field)
+	}
+	return visible
+}
+
+// metadataLines renders fields as "Label: value" lines with the values aligned after the longest label
+// Values wider than width columns (0 for unlimited) are handled by overflow, wrapped lines are indented
+// under the value column.
+func metadataLines(fields []MetadataField, width int, overflow Overflow) []BannerLine {
+	labelWidth := minMetadataLabelWidth
+	for _, field := range fields {
+		if labelLen := visibleWidth(field.Key) + 1; labelLen > labelWidth {
+			labelWidth = labelLen
+		}
+	}
+
+	valueWidth := 0
+	if width > 0 {
+		valueWidth = max(width-labelWidth-1, 1)
+	}
+	lines := make([]BannerLine, 0, len(fields))
+	for _, field := range fields {
+		label := field.Key + ":"
+		for idx, value := range fitText(field.Value, valueWidth, overflow) {
+			if idx == 0 {
+				lines = append(lines, newLine(SectionMetadata,
+					BannerSpan{Text: label, Role: RoleLabel},
+					spaces(labelWidth-visibleWidth(label)+1),
+					BannerSpan{Text: value, Role: RoleValue}))
+			} else {
+				lines = append(lines, newLine(SectionMetadata, spaces(labelWidth+1), BannerSpan{Text: value, Role: RoleValue}))
+			}
+		}
+	}
+	return lines
+}
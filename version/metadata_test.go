@@ -0,0 +1,98 @@
+package version
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMetadataFields(t *testing.T) {
+	info := &Info{Author: "Jane", Repo: "github.com/acme/tool"}
+	info.SetMetadata("License", "MIT")
+	info.SetMetadata("Docs", "https://docs.acme.dev")
+	info.SetMetadata("license", "Apache-2.0")
+	info.HideMetadata("Repo")
+
+	got := info.MetadataFields(MetadataField{Key: "Region", Value: "eu-west-1"}, MetadataField{Key: "Docs", Hidden: true})
+	want := []MetadataField{
+		{Key: "Author", Value: "Jane"},
+		{Key: "License", Value: "Apache-2.0"},
+		{Key: "Region", Value: "eu-west-1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MetadataFields() = %+v, want %+v", got, want)
+	}
+
+	info.SetMetadata("Repo", "github.com/acme/fork")
+	if got := info.MetadataFields(); got[1] != (MetadataField{Key: "Repo", Value: "github.com/acme/fork"}) {
+		t.Errorf("SetMetadata() should replace and show the built-in field, got %+v", got)
+	}
+}
+
+func TestBannerMetadataAlignment(t *testing.T) {
+	tests := []struct {
+		name string
+		info *Info
+		opts BannerOptions
+		want []string
+	}{
+		{
+			name: "built-in labels keep their column",
+			info: &Info{Major: 1, Author: "Jane", Metadata: []MetadataField{{Key: "License", Value: "MIT"}}},
+			want: []string{"Author:    Jane", "License:   MIT"},
+		},
+		{
+			name: "longest label sets the column",
+			info: &Info{Major: 1, Author: "Jane", Metadata: []MetadataField{{Key: "Environment", Value: "production"}}},
+			want: []string{"Author:      Jane", "Environment: production"},
+		},
+		{
+			name: "banner options add and hide fields",
+			info: &Info{Major: 1, Author: "Jane", Company: "Acme"},
+			opts: BannerOptions{Metadata: []MetadataField{{Key: "Author", Hidden: true}, {Key: "Support", Value: "help@acme.dev"}}},
+			want: []string{"Company:   Acme", "Support:   help@acme.dev"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.AutoWidth = true
+			got := BannerWithOptions("App", tt.info, tt.opts)
+			if want := "v1.0.0\n\n" + strings.Join(tt.want, "\n") + "\n"; !strings.Contains(got, want) {
+				t.Errorf("BannerWithOptions() = %q, want it to contain %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBannerMetadataWidth(t *testing.T) {
+	showBorder := true
+	info := &Info{Major: 1, Metadata: []MetadataField{{Key: "Docs", Value: "https://docs.example.com/tool/latest"}}}
+	got := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, ShowBorder: &showBorder})
+
+	lines := strings.Split(got, "\n")
+	if want := len("Docs:      https://docs.example.com/tool/latest") + 4; len(lines[0]) != want {
+		t.Errorf("border width = %d, want %d:\n%s", len(lines[0]), want, got)
+	}
+}
+
+func TestMetadataJSON(t *testing.T) {
+	info := &Info{Major: 1, Metadata: []MetadataField{{Key: "License", Value: "MIT"}, {Key: "Region", Value: "us-east-1", Hidden: true}}}
+
+	got, err := info.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if want := `{"version":"1.0.0","metadata":[{"key":"License","value":"MIT"}]}`; got != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+
+	data, err := json.Marshal(newExpvarInfo(info))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"metadata":[{"key":"License","value":"MIT"}]`) {
+		t.Errorf("expvar = %s, want the License metadata", data)
+	}
+}
@@ -0,0 +1,170 @@
+package version
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	prometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// BuildInfoMetric renders a <app>_build_info gauge in the Prometheus text exposition format
+// Example:
+//
+//	# HELP myapp_build_info A metric with a constant '1' value labeled by version, revision, branch, and goversion from which myapp was built.
+//	# TYPE myapp_build_info gauge
+//	myapp_build_info{version="1.2.3-beta",revision="ABC123",branch="main",goversion="go1.24.2"} 1
+func BuildInfoMetric(appName string, info *Info) string {
+	return renderBuildInfo(appName, info, false)
+}
+
+// BuildInfoOpenMetrics renders the <app>_build_info gauge in the OpenMetrics text format
+func BuildInfoOpenMetrics(appName string, info *Info) string {
+	return renderBuildInfo(appName, info, true)
+}
+
+// MetricsHandler returns an http.Handler exposing the build_info gauge, in the OpenMetrics format when
+// the Accept header prefers application/openmetrics-text and in the Prometheus text format otherwise
+func MetricsHandler(appName string, info *Info) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, contentType := BuildInfoMetric(appName, info), prometheusContentType
+		if prefersOpenMetrics(r.Header.Get("Accept")) {
+			body, contentType = BuildInfoOpenMetrics(appName, info), openMetricsContentType
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Add("Vary", "Accept")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// renderBuildInfo renders the build_info metric family in either exposition format
+func renderBuildInfo(appName string, info *Info, openMetrics bool) string {
+	name := metricName(appName)
+	if name == "" {
+		name = "build_info"
+	} else {
+		name += "_build_info"
+	}
+
+	help := fmt.Sprintf("A metric with a constant '1' value labeled by version, revision, branch, and goversion from which %s was built.", appName)
+	if appName == "" {
+		help = "A metric with a constant '1' value labeled by version, revision, branch, and goversion from which the application was built."
+	}
+
+	labels := []struct{ name, value string }{
+		{"version", formatVersionString(info.Major, info.Minor, info.Patch, "", info.Suffix)},
+		{"revision", info.Hash},
+		{"branch", info.Branch},
+		{"goversion", runtime.Version()},
+	}
+
+	var pairs []string
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label.name, escapeLabelValue(label.value)))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# HELP %s %s\n", name, escapeHelp(help, openMetrics))
+	fmt.Fprintf(&sb, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(&sb, "%s{%s} 1\n", name, strings.Join(pairs, ","))
+	if openMetrics {
+		sb.WriteString("# EOF\n")
+	}
+	return sb.String()
+}
+
+// metricName turns an application name into a valid metric name prefix ([a-zA-Z_:][a-zA-Z0-9_:]*),
+// lower-casing it and replacing every other character with an underscore
+func metricName(appName string) string {
+	var sb strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(appName)) {
+		valid := r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+		if !valid {
+			r = '_'
+		}
+		if r == '_' && lastUnderscore {
+			continue
+		}
+		lastUnderscore = r == '_'
+		sb.WriteRune(r)
+	}
+
+	name := strings.Trim(sb.String(), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// escapeLabelValue escapes backslashes, double quotes and line feeds in a label value
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// escapeHelp escapes backslashes and line feeds in a HELP docstring, and double quotes in the OpenMetrics format
+func escapeHelp(help string, openMetrics bool) string {
+	if openMetrics {
+		return escapeLabelValue(help)
+	}
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// prefersOpenMetrics reports whether the Accept header names application/openmetrics-text with a non-zero
+// quality that is at least the quality of the Prometheus text format
+func prefersOpenMetrics(accept string) bool {
+	openMetrics, named := acceptQuality(accept, "application/openmetrics-text")
+	text, _ := acceptQuality(accept, "text/plain")
+	return named && openMetrics > 0 && openMetrics >= text
+}
+
+// acceptQuality returns the q value given to mediaType by its most specific media range in the Accept header
+// (0 when no range matches), and whether that range names mediaType itself rather than a wildcard
+func acceptQuality(accept, mediaType string) (float64, bool) {
+	quality, specificity := 0.0, -1
+	for _, mediaRange := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		rangeSpecificity := -1
+		switch {
+		case rangeType == mediaType:
+			rangeSpecificity = 2
+		case rangeType == "*/*":
+			rangeSpecificity = 0
+		case strings.HasSuffix(rangeType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(rangeType, "*")):
+			rangeSpecificity = 1
+		}
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		quality, specificity = q, rangeSpecificity
+	}
+	return quality, specificity == 2
+}
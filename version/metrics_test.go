@@ -0,0 +1,116 @@
+package version
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBuildInfoMetric(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "beta", Branch: `feature/"quoted"\path`}
+
+	got := BuildInfoMetric("MyApp", info)
+	want := "# HELP myapp_build_info A metric with a constant '1' value labeled by version, revision, branch, and goversion from which MyApp was built.\n" +
+		"# TYPE myapp_build_info gauge\n" +
+		`myapp_build_info{version="1.2.3-beta",revision="ABC123",branch="feature/\"quoted\"\\path",goversion="` + runtime.Version() + `"} 1` + "\n"
+	if got != want {
+		t.Errorf("BuildInfoMetric() =\n%s\nwant\n%s", got, want)
+	}
+
+	openMetrics := BuildInfoOpenMetrics("MyApp", info)
+	if !strings.HasPrefix(openMetrics, "# HELP myapp_build_info") || !strings.HasSuffix(openMetrics, "} 1\n# EOF\n") {
+		t.Errorf("BuildInfoOpenMetrics() = %q, want HELP header and # EOF trailer", openMetrics)
+	}
+}
+
+func TestBuildInfoQuotedAppName(t *testing.T) {
+	info := &Info{Major: 1, Minor: 0, Patch: 0}
+	appName := `My "App"\n`
+
+	help := "A metric with a constant '1' value labeled by version, revision, branch, and goversion from which "
+	if got, want := BuildInfoMetric(appName, info), "# HELP my_app_n_build_info "+help+`My "App"\\n was built.`+"\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("BuildInfoMetric() =\n%s\nwant prefix\n%s", got, want)
+	}
+	if got, want := BuildInfoOpenMetrics(appName, info), "# HELP my_app_n_build_info "+help+`My \"App\"\\n was built.`+"\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("BuildInfoOpenMetrics() =\n%s\nwant prefix\n%s", got, want)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		appName string
+		want    string
+	}{
+		{appName: "myapp", want: "myapp"},
+		{appName: "MoneyGrow AI", want: "moneygrow_ai"},
+		{appName: "api-gateway.v2", want: "api_gateway_v2"},
+		{appName: "  __svc:edge__ ", want: "svc:edge"},
+		{appName: "9lives", want: "_9lives"},
+		{appName: "Ünïcode", want: "n_code"},
+		{appName: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.appName, func(t *testing.T) {
+			if got := metricName(tt.appName); got != tt.want {
+				t.Errorf("metricName(%q) = %q, want %q", tt.appName, got, tt.want)
+			}
+		})
+	}
+
+	if got := BuildInfoMetric("", &Info{}); !strings.Contains(got, "\nbuild_info{") {
+		t.Errorf("BuildInfoMetric() without app name = %q, want bare build_info metric", got)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	got := escapeLabelValue("a\\b\"c\nd")
+	want := `a\\b\"c\nd`
+	if got != want {
+		t.Errorf("escapeLabelValue() = %q, want %q", got, want)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	handler := MetricsHandler("svc", &Info{Major: 0, Minor: 1, Patch: 0})
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantEOF         bool
+	}{
+		{name: "prometheus text", wantContentType: "text/plain; version=0.0.4; charset=utf-8"},
+		{name: "openmetrics", accept: "application/openmetrics-text;version=1.0.0,text/plain;q=0.5", wantContentType: "application/openmetrics-text; version=1.0.0; charset=utf-8", wantEOF: true},
+		{name: "prometheus scraper", accept: "application/openmetrics-text;version=1.0.0;q=0.5,text/plain;version=0.0.4;q=0.4,*/*;q=0.1", wantContentType: "application/openmetrics-text; version=1.0.0; charset=utf-8", wantEOF: true},
+		{name: "openmetrics refused", accept: "application/openmetrics-text;q=0, text/plain", wantContentType: "text/plain; version=0.0.4; charset=utf-8"},
+		{name: "openmetrics refused alone", accept: "Application/OpenMetrics-Text; q=0.0", wantContentType: "text/plain; version=0.0.4; charset=utf-8"},
+		{name: "text preferred", accept: "application/openmetrics-text;q=0.3,text/*;q=0.8", wantContentType: "text/plain; version=0.0.4; charset=utf-8"},
+		{name: "wildcard", accept: "*/*", wantContentType: "text/plain; version=0.0.4; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			body, _ := io.ReadAll(rec.Body)
+			if rec.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if !strings.Contains(string(body), `svc_build_info{version="0.1.0"`) {
+				t.Errorf("body = %q, want svc_build_info sample", body)
+			}
+			if strings.HasSuffix(string(body), "# EOF\n") != tt.wantEOF {
+				t.Errorf("body = %q, # EOF trailer presence want %t", body, tt.wantEOF)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultVersionHeader is the response header advertising the server version
+	DefaultVersionHeader = "X-App-Version"
+	// DefaultClientVersionHeader is the request header carrying the client version
+	DefaultClientVersionHeader = "X-Client-Version"
+
+	problemContentType = "application/problem+json"
+)
+
+// MiddlewareOptions configures the middleware returned by MiddlewareWithOptions
+type MiddlewareOptions struct {
+	// Header is the response header carrying the server version (defaults to X-App-Version)
+	Header string
+	// ClientHeader is the request header carrying the client version (defaults to X-Client-Version)
+	ClientHeader string
+	// ClientConstraint is the range client versions must satisfy, e.g. ">=1.4.0" (nil disables the check)
+	ClientConstraint *Constraint
+	// RequireClientVersion rejects requests without a client version when ClientConstraint is set
+	RequireClientVersion bool
+	// UpgradeProtocol is advertised in the Upgrade header of 426 responses to HTTP/1.x requests, e.g. "HTTP/2.0"
+	// (empty sends none: the upgrade asked for is the client version, not the protocol, and HTTP/2 forbids the header)
+	UpgradeProtocol string
+}
+
+// problem is an RFC 7807 problem details body returned when a client version is rejected
+type problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail"`
+	ClientVersion string `json:"client_version,omitempty"`
+	Required      string `json:"required,omitempty"`
+}
+
+// Middleware returns HTTP middleware adding the X-App-Version header to every response
+func Middleware(info *Info) func(http.Handler) http.Handler {
+	return MiddlewareWithOptions(info, MiddlewareOptions{})
+}
+
+// MiddlewareWithOptions returns HTTP middleware advertising the server version and, when a
+// ClientConstraint is set, rejecting clients that are too old with 426 Upgrade Required
+func MiddlewareWithOptions(info *Info, opts MiddlewareOptions) func(http.Handler) http.Handler {
+	if opts.Header == "" {
+		opts.Header = DefaultVersionHeader
+	}
+	if opts.ClientHeader == "" {
+		opts.ClientHeader = DefaultClientVersionHeader
+	}
+	serverVersion := headerVersion(info)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(opts.Header, serverVersion)
+
+			if opts.ClientConstraint != nil {
+				if p := checkClientVersion(r.Header.Get(opts.ClientHeader), opts); p != nil {
+					if p.Status == http.StatusUpgradeRequired {
+						setUpgradeHeader(w, r, opts.UpgradeProtocol)
+					}
+					writeProblem(w, p)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setUpgradeHeader advertises protocol on a 426 response when the request used a different HTTP/1.x protocol
+// HTTP/2 and HTTP/3 forbid connection-specific fields such as Upgrade, and Connection is left to net/http.
+func setUpgradeHeader(w http.ResponseWriter, r *http.Request, protocol string) {
+	if protocol == "" || r.ProtoMajor != 1 || strings.EqualFold(protocol, r.Proto) {
+		return
+	}
+	w.Header().Set("Upgrade", protocol)
+}
+
+// checkClientVersion returns a problem when the client version does not satisfy the constraint
+func checkClientVersion(clientVersion string, opts MiddlewareOptions) *problem {
+	required := opts.ClientConstraint.String()
+
+	if clientVersion == "" {
+		if !opts.RequireClientVersion {
+			return nil
+		}
+		return &problem{
+			Type:     "about:blank",
+			Title:    "Upgrade Required",
+			Status:   http.StatusUpgradeRequired,
+			Detail:   fmt.Sprintf("the %s header is required", opts.ClientHeader),
+			Required: required,
+		}
+	}
+
+	client, err := Parse(clientVersion)
+	if err != nil {
+		return &problem{
+			Type:          "about:blank",
+			Title:         "Bad Request",
+			Status:        http.StatusBadRequest,
+			Detail:        fmt.Sprintf("invalid %s header: %v", opts.ClientHeader, err),
+			ClientVersion: clientVersion,
+			Required:      required,
+		}
+	}
+
+	if !opts.ClientConstraint.Check(client) {
+		return &problem{
+			Type:          "about:blank",
+			Title:         "Upgrade Required",
+			Status:        http.StatusUpgradeRequired,
+			Detail:        fmt.Sprintf("client version %s is not supported, please upgrade to a version matching %s", client.Short(), required),
+			ClientVersion: clientVersion,
+			Required:      required,
+		}
+	}
+
+	return nil
+}
+
+// writeProblem writes a problem details response
+func writeProblem(w http.ResponseWriter, p *problem) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, p.Detail, p.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(body)
+}
+
+// Transport is an http.RoundTripper adding the client's own version to outgoing requests
+type Transport struct {
+	// Base is the underlying transport (defaults to http.DefaultTransport)
+	Base http.RoundTripper
+	// Info is the version advertised to servers
+	Info *Info
+	// Header is the request header carrying the version (defaults to X-Client-Version)
+	Header string
+}
+
+// NewTransport returns a Transport advertising info on top of base
+func NewTransport(base http.RoundTripper, info *Info) *Transport {
+	return &Transport{Base: base, Info: info}
+}
+
+// RoundTrip implements http.RoundTripper, the original request is left untouched
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	header := t.Header
+	if header == "" {
+		header = DefaultClientVersionHeader
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Header.Set(header, headerVersion(t.Info))
+	return base.RoundTrip(clone)
+}
+
+// ResponseVersion parses the server version advertised in a response header (X-App-Version when header is empty)
+func ResponseVersion(resp *http.Response, header string) (*Info, error) {
+	if header == "" {
+		header = DefaultVersionHeader
+	}
+	value := resp.Header.Get(header)
+	if value == "" {
+		return nil, fmt.Errorf("response has no %s header", header)
+	}
+	return Parse(value)
+}
+
+// headerVersion formats a version for use in a header, in the format accepted by Parse
+func headerVersion(info *Info) string {
+	return formatVersionString(info.Major, info.Minor, info.Patch, info.Hash, info.Suffix)
+}
@@ -0,0 +1,222 @@
+package version
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareClientCheck(t *testing.T) {
+	server := &Info{Major: 2, Minor: 1, Patch: 0, Hash: "ABC123"}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	tests := []struct {
+		name          string
+		opts          MiddlewareOptions
+		clientHeader  string
+		clientVersion string
+		wantStatus    int
+		wantHeader    string
+	}{
+		{
+			name:       "no constraint",
+			wantStatus: http.StatusOK,
+			wantHeader: DefaultVersionHeader,
+		},
+		{
+			name:          "client satisfies constraint",
+			opts:          MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0")},
+			clientVersion: "1.5.0",
+			wantStatus:    http.StatusOK,
+			wantHeader:    DefaultVersionHeader,
+		},
+		{
+			name:          "client too old",
+			opts:          MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0")},
+			clientVersion: "1.3.9",
+			wantStatus:    http.StatusUpgradeRequired,
+			wantHeader:    DefaultVersionHeader,
+		},
+		{
+			name:          "pre-release below minimum",
+			opts:          MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0")},
+			clientVersion: "1.4.0-beta",
+			wantStatus:    http.StatusUpgradeRequired,
+			wantHeader:    DefaultVersionHeader,
+		},
+		{
+			name:          "invalid client version",
+			opts:          MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0")},
+			clientVersion: "latest",
+			wantStatus:    http.StatusBadRequest,
+			wantHeader:    DefaultVersionHeader,
+		},
+		{
+			name:       "missing client version allowed",
+			opts:       MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0")},
+			wantStatus: http.StatusOK,
+			wantHeader: DefaultVersionHeader,
+		},
+		{
+			name:       "missing client version required",
+			opts:       MiddlewareOptions{ClientConstraint: MustParseConstraint(">=1.4.0"), RequireClientVersion: true},
+			wantStatus: http.StatusUpgradeRequired,
+			wantHeader: DefaultVersionHeader,
+		},
+		{
+			name: "custom headers",
+			opts: MiddlewareOptions{
+				Header:           "X-Server",
+				ClientHeader:     "X-Cli",
+				ClientConstraint: MustParseConstraint("^2.0.0"),
+				UpgradeProtocol:  "HTTP/2.0",
+			},
+			clientHeader:  "X-Cli",
+			clientVersion: "1.9.0",
+			wantStatus:    http.StatusUpgradeRequired,
+			wantHeader:    "X-Server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.clientVersion != "" {
+				header := tt.clientHeader
+				if header == "" {
+					header = DefaultClientVersionHeader
+				}
+				req.Header.Set(header, tt.clientVersion)
+			}
+			rec := httptest.NewRecorder()
+
+			MiddlewareWithOptions(server, tt.opts)(ok).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get(tt.wantHeader); got != "2.1.0:ABC123" {
+				t.Errorf("%s = %q, want %q", tt.wantHeader, got, "2.1.0:ABC123")
+			}
+			wantUpgrade := ""
+			if tt.wantStatus == http.StatusUpgradeRequired {
+				wantUpgrade = tt.opts.UpgradeProtocol
+			}
+			if got := rec.Header().Get("Upgrade"); got != wantUpgrade {
+				t.Errorf("Upgrade = %q, want %q", got, wantUpgrade)
+			}
+			if got := rec.Header().Get("Connection"); got != "" {
+				t.Errorf("Connection = %q, want it left to net/http", got)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			if rec.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get("Content-Type"), "application/problem+json")
+			}
+			var p problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("problem body is not JSON: %v", err)
+			}
+			if p.Status != tt.wantStatus || p.Required != tt.opts.ClientConstraint.String() || p.Detail == "" {
+				t.Errorf("problem = %+v, want status %d and required %q", p, tt.wantStatus, tt.opts.ClientConstraint.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareUpgradeHeader(t *testing.T) {
+	server := &Info{Major: 2}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	tooOld := func(protocol string) http.Handler {
+		return MiddlewareWithOptions(server, MiddlewareOptions{
+			ClientConstraint: MustParseConstraint(">=2.0.0"),
+			UpgradeProtocol:  protocol,
+		})(ok)
+	}
+
+	t.Run("same protocol as the request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(DefaultClientVersionHeader, "1.0.0")
+		rec := httptest.NewRecorder()
+		tooOld("HTTP/1.1").ServeHTTP(rec, req)
+		if rec.Code != http.StatusUpgradeRequired || rec.Header().Get("Upgrade") != "" {
+			t.Errorf("status = %d, Upgrade = %q, want 426 without Upgrade", rec.Code, rec.Header().Get("Upgrade"))
+		}
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		ts := httptest.NewUnstartedServer(tooOld("HTTP/3.0"))
+		ts.EnableHTTP2 = true
+		ts.StartTLS()
+		defer ts.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+		req.Header.Set(DefaultClientVersionHeader, "1.0.0")
+		resp, err := ts.Client().Do(req)
+		if err != nil {
+			t.Fatalf("request error = %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.ProtoMajor != 2 {
+			t.Fatalf("response protocol = %s, want HTTP/2", resp.Proto)
+		}
+		if resp.StatusCode != http.StatusUpgradeRequired {
+			t.Errorf("status = %d, want 426", resp.StatusCode)
+		}
+		for _, name := range []string{"Upgrade", "Connection"} {
+			if got := resp.Header.Values(name); len(got) > 0 {
+				t.Errorf("%s = %v, want no connection-specific header over HTTP/2", name, got)
+			}
+		}
+	})
+}
+
+func TestTransportNegotiatesVersions(t *testing.T) {
+	server := &Info{Major: 3, Minor: 0, Patch: 0}
+	client := &Info{Major: 2, Minor: 4, Patch: 1, Suffix: "beta"}
+
+	var seen string
+	handler := MiddlewareWithOptions(server, MiddlewareOptions{ClientConstraint: MustParseConstraint(">=2.0.0-alpha")})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = r.Header.Get(DefaultClientVersionHeader)
+		}))
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	httpClient := &http.Client{Transport: NewTransport(nil, client)}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if seen != "2.4.1-beta" {
+		t.Errorf("server saw client version %q, want %q", seen, "2.4.1-beta")
+	}
+	if req.Header.Get(DefaultClientVersionHeader) != "" {
+		t.Errorf("RoundTrip modified the original request headers")
+	}
+
+	got, err := ResponseVersion(resp, "")
+	if err != nil {
+		t.Fatalf("ResponseVersion() error = %v", err)
+	}
+	if !got.Equal(server) {
+		t.Errorf("ResponseVersion() = %s, want %s", got.Short(), server.Short())
+	}
+
+	if _, err := ResponseVersion(&http.Response{Header: http.Header{}}, ""); err == nil {
+		t.Errorf("ResponseVersion() without header expected error but got none")
+	}
+}
@@ -0,0 +1,214 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ErrStoreNewer is returned by Migrator.Run when the stored version is newer than the running binary
+var ErrStoreNewer = errors.New("data store was written by a newer version")
+
+// MigrationFunc upgrades a data store to the version it is registered for
+type MigrationFunc func(ctx context.Context) error
+
+// VersionStore persists the version a data store was last migrated to
+type VersionStore interface {
+	// Load returns the stored version, or nil when the store has never been migrated
+	Load(ctx context.Context) (*Info, error)
+	// Save records the version the store has been migrated to
+	Save(ctx context.Context, info *Info) error
+}
+
+// FileVersionStore keeps the stored version in a small JSON file
+type FileVersionStore struct {
+	Path string
+}
+
+// fileVersionState is the content of a FileVersionStore file
+type fileVersionState struct {
+	Version string `json:"version"`
+}
+
+// Load implements VersionStore, a missing file meaning the store has never been migrated
+func (s *FileVersionStore) Load(ctx context.Context) (*Info, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read version store: %w", err)
+	}
+
+	var state fileVersionState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("invalid version store %s: %w", s.Path, err)
+	}
+	return Parse(state.Version)
+}
+
+// Save implements VersionStore, replacing the file atomically
+func (s *FileVersionStore) Save(ctx context.Context, info *Info) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(fileVersionState{Version: formatVersionString(info.Major, info.Minor, info.Patch, info.Hash, info.Suffix)})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.Path, raw, 0o600); err != nil {
+		return fmt.Errorf("cannot write version store: %w", err)
+	}
+	return nil
+}
+
+// migration is a registered step
+type migration struct {
+	target *Info
+	fn     MigrationFunc
+}
+
+// Migrator runs the registered migration steps between the stored version and the running version
+type Migrator struct {
+	// DryRun reports the pending steps without running them or saving progress
+	DryRun bool
+
+	steps []migration
+}
+
+// MigrationResult is the outcome of Migrator.Run
+type MigrationResult struct {
+	// From is the stored version before running, nil for a store that was never migrated
+	From *Info
+	// To is the running version
+	To *Info
+	// Applied lists the targets of the steps that ran (or would run in dry-run mode), in order
+	Applied []*Info
+	// DryRun is true when no step was actually run
+	DryRun bool
+}
+
+// NewMigrator returns an empty migrator
+func NewMigrator() *Migrator {
+	return &Migrator{}
+}
+
+// Register adds a step upgrading the store to target, a version string such as "1.4.0"
+func (m *Migrator) Register(target string, fn MigrationFunc) error {
+	if fn == nil {
+		return fmt.Errorf("migration to %s has no function", target)
+	}
+	info, err := Parse(target)
+	if err != nil {
+		return fmt.Errorf("invalid migration target: %w", err)
+	}
+	for _, step := range m.steps {
+		if Compare(step.target, info) == 0 {
+			return fmt.Errorf("migration to %s is already registered", target)
+		}
+	}
+
+	m.steps = append(m.steps, migration{target: info, fn: fn})
+	sort.SliceStable(m.steps, func(a, b int) bool {
+		return Compare(m.steps[a].target, m.steps[b].target) < 0
+	})
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func (m *Migrator) MustRegister(target string, fn MigrationFunc) {
+	if err := m.Register(target, fn); err != nil {
+		panic(err)
+	}
+}
+
+// Pending returns the targets of the steps above stored and up to current, in precedence order
+// A nil stored version selects every step up to current.
+func (m *Migrator) Pending(stored, current *Info) ([]*Info, error) {
+	steps, err := m.pendingSteps(stored, current)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Info
+	for _, step := range steps {
+		pending = append(pending, step.target)
+	}
+	return pending, nil
+}
+
+// Run loads the stored version, runs the pending steps in precedence order and saves the
+// version after each successful step, so an interrupted run resumes where it stopped.
+// Once every step has run the store is stamped with current.
+func (m *Migrator) Run(ctx context.Context, store VersionStore, current *Info) (*MigrationResult, error) {
+	if store == nil {
+		return nil, errors.New("migrator needs a version store")
+	}
+
+	stored, err := store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := m.pendingSteps(stored, current)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{From: stored, To: current, DryRun: m.DryRun}
+	if m.DryRun {
+		for _, step := range steps {
+			result.Applied = append(result.Applied, step.target)
+		}
+		return result, nil
+	}
+
+	saved := stored
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := step.fn(ctx); err != nil {
+			return result, fmt.Errorf("migration to %s failed: %w", step.target.Short(), err)
+		}
+		if err := store.Save(ctx, step.target); err != nil {
+			return result, fmt.Errorf("cannot record migration to %s: %w", step.target.Short(), err)
+		}
+		saved = step.target
+		result.Applied = append(result.Applied, step.target)
+	}
+
+	// The last step may already have recorded current
+	if saved == nil || Compare(saved, current) != 0 || !strings.EqualFold(saved.Hash, current.Hash) {
+		if err := store.Save(ctx, current); err != nil {
+			return result, fmt.Errorf("cannot record version %s: %w", current.Short(), err)
+		}
+	}
+	return result, nil
+}
+
+// pendingSteps returns the steps above stored and up to current, refusing a stored version newer than current
+func (m *Migrator) pendingSteps(stored, current *Info) ([]migration, error) {
+	if current == nil {
+		return nil, errors.New("current version cannot be nil")
+	}
+	if stored != nil && Compare(stored, current) > 0 {
+		return nil, fmt.Errorf("%w: stored %s, running %s", ErrStoreNewer, stored.Short(), current.Short())
+	}
+
+	var steps []migration
+	for _, step := range m.steps {
+		if Compare(step.target, stored) > 0 && Compare(step.target, current) <= 0 {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
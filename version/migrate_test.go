@@ -0,0 +1,191 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// memoryVersionStore is a VersionStore recording every saved version
+type memoryVersionStore struct {
+	stored *Info
+	saves  []string
+}
+
+func (s *memoryVersionStore) Load(ctx context.Context) (*Info, error) {
+	return s.stored, nil
+}
+
+func (s *memoryVersionStore) Save(ctx context.Context, info *Info) error {
+	s.stored = info
+	s.saves = append(s.saves, info.Short())
+	return nil
+}
+
+// newTestMigrator registers steps for the given targets, appending each target to ran when executed
+func newTestMigrator(t *testing.T, ran *[]string, targets ...string) *Migrator {
+	t.Helper()
+	m := NewMigrator()
+	for _, target := range targets {
+		if err := m.Register(target, func(ctx context.Context) error {
+			*ran = append(*ran, target)
+			return nil
+		}); err != nil {
+			t.Fatalf("Register(%s) unexpected error: %v", target, err)
+		}
+	}
+	return m
+}
+
+func TestMigratorRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    string
+		current   string
+		wantRan   []string
+		wantSaves []string
+	}{
+		{
+			name:      "runs pending steps in precedence order",
+			stored:    "1.0.0",
+			current:   "1.3.0",
+			wantRan:   []string{"1.1.0-beta", "1.1.0", "1.3.0"},
+			wantSaves: []string{"v1.1.0-beta", "v1.1.0", "v1.3.0"},
+		},
+		{
+			name:      "skips steps newer than the binary",
+			stored:    "1.1.0-beta",
+			current:   "1.2.0",
+			wantRan:   []string{"1.1.0"},
+			wantSaves: []string{"v1.1.0", "v1.2.0"},
+		},
+		{
+			name:      "never migrated store runs every step",
+			current:   "1.1.0",
+			wantRan:   []string{"1.1.0-beta", "1.1.0"},
+			wantSaves: []string{"v1.1.0-beta", "v1.1.0"},
+		},
+		{
+			name:    "up to date",
+			stored:  "1.3.0",
+			current: "1.3.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			m := newTestMigrator(t, &ran, "1.3.0", "1.1.0", "1.4.0", "1.1.0-beta")
+			store := &memoryVersionStore{}
+			if tt.stored != "" {
+				store.stored = mustParse(t, tt.stored)
+			}
+
+			result, err := m.Run(context.Background(), store, mustParse(t, tt.current))
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(ran, tt.wantRan) {
+				t.Errorf("ran = %v, want %v", ran, tt.wantRan)
+			}
+			if !reflect.DeepEqual(store.saves, tt.wantSaves) {
+				t.Errorf("saves = %v, want %v", store.saves, tt.wantSaves)
+			}
+			if len(result.Applied) != len(tt.wantRan) {
+				t.Errorf("Applied = %v, want %d steps", result.Applied, len(tt.wantRan))
+			}
+		})
+	}
+}
+
+func TestMigratorDryRun(t *testing.T) {
+	var ran []string
+	m := newTestMigrator(t, &ran, "1.1.0", "1.2.0")
+	m.DryRun = true
+	store := &memoryVersionStore{stored: mustParse(t, "1.0.0")}
+
+	result, err := m.Run(context.Background(), store, mustParse(t, "1.2.0"))
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if len(ran) != 0 || len(store.saves) != 0 {
+		t.Errorf("dry run ran %v and saved %v, want nothing", ran, store.saves)
+	}
+	if !result.DryRun || len(result.Applied) != 2 || result.Applied[0].Short() != "v1.1.0" {
+		t.Errorf("result = %+v, want two pending steps", result)
+	}
+}
+
+func TestMigratorStopsOnFailure(t *testing.T) {
+	var ran []string
+	m := newTestMigrator(t, &ran, "1.1.0", "1.3.0")
+	boom := errors.New("boom")
+	m.MustRegister("1.2.0", func(ctx context.Context) error { return boom })
+	store := &memoryVersionStore{stored: mustParse(t, "1.0.0")}
+
+	result, err := m.Run(context.Background(), store, mustParse(t, "1.3.0"))
+	if !errors.Is(err, boom) {
+		t.Fatalf("Run() error = %v, want boom", err)
+	}
+	if !reflect.DeepEqual(store.saves, []string{"v1.1.0"}) || len(result.Applied) != 1 {
+		t.Errorf("saves = %v, Applied = %v, want progress up to 1.1.0", store.saves, result.Applied)
+	}
+
+	// A later run resumes after the last recorded step
+	pending, _ := m.Pending(store.stored, mustParse(t, "1.3.0"))
+	if len(pending) != 2 || pending[0].Short() != "v1.2.0" {
+		t.Errorf("Pending() = %v, want 1.2.0 and 1.3.0", pending)
+	}
+}
+
+func TestMigratorRefusesNewerStore(t *testing.T) {
+	var ran []string
+	m := newTestMigrator(t, &ran, "1.1.0")
+	store := &memoryVersionStore{stored: mustParse(t, "2.0.0")}
+
+	if _, err := m.Run(context.Background(), store, mustParse(t, "1.5.0")); !errors.Is(err, ErrStoreNewer) {
+		t.Errorf("Run() error = %v, want ErrStoreNewer", err)
+	}
+	if len(ran) != 0 || len(store.saves) != 0 {
+		t.Errorf("refused run ran %v and saved %v, want nothing", ran, store.saves)
+	}
+}
+
+func TestMigratorRegisterInvalid(t *testing.T) {
+	m := NewMigrator()
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := m.Register("not-a-version", noop); err == nil {
+		t.Errorf("Register() with invalid target expected error but got none")
+	}
+	if err := m.Register("1.0.0", nil); err == nil {
+		t.Errorf("Register() with nil function expected error but got none")
+	}
+	m.MustRegister("1.0.0", noop)
+	if err := m.Register("1.0.0:ABC123", noop); err == nil {
+		t.Errorf("Register() with duplicate target expected error but got none")
+	}
+}
+
+func TestFileVersionStore(t *testing.T) {
+	store := &FileVersionStore{Path: filepath.Join(t.TempDir(), "data", "version.json")}
+	ctx := context.Background()
+
+	stored, err := store.Load(ctx)
+	if err != nil || stored != nil {
+		t.Fatalf("Load() on missing file = %v, %v, want nil and no error", stored, err)
+	}
+
+	var ran []string
+	m := newTestMigrator(t, &ran, "1.1.0")
+	if _, err := m.Run(ctx, store, mustParse(t, "1.2.0-rc1")); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	stored, err = store.Load(ctx)
+	if err != nil || stored.Short() != "v1.2.0-rc1" {
+		t.Errorf("Load() = %v, %v, want v1.2.0-rc1", stored, err)
+	}
+}
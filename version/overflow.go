@@ -0,0 +1,123 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Overflow selects what happens to metadata and notices wider than the banner
+type Overflow string
+
+const (
+	// OverflowClip - cut the line at the edge (the default)
+	OverflowClip Overflow = "clip"
+	// OverflowEllipsis - cut the line and end it with "…"
+	OverflowEllipsis Overflow = "ellipsis"
+	// OverflowWrap - break the line at the edge, even inside a word
+	OverflowWrap Overflow = "wrap"
+	// OverflowWordWrap - break the line between words, moving URLs and other long words whole to the next line
+	OverflowWordWrap Overflow = "word-wrap"
+)
+
+// ellipsis ends lines cut by OverflowEllipsis
+const ellipsis = "…"
+
+// ParseOverflow parses an overflow policy: clip, ellipsis, wrap or word-wrap
+func ParseOverflow(s string) (Overflow, error) {
+	switch overflow := Overflow(strings.ToLower(strings.TrimSpace(s))); overflow {
+	case OverflowClip, OverflowEllipsis, OverflowWrap, OverflowWordWrap:
+		return overflow, nil
+	}
+	return "", fmt.Errorf("invalid overflow: %s (expected: clip, ellipsis, wrap or word-wrap)", s)
+}
+
+// fitText applies overflow to s so that every returned line fits in width columns
+// A width of 0 or less means unlimited.
+func fitText(s string, width int, overflow Overflow) []string {
+	if width <= 0 || ansiWidth(s) <= width {
+		return []string{s}
+	}
+
+	switch overflow {
+	case OverflowEllipsis:
+		return []string{truncateANSI(s, width-ansiWidth(ellipsis)) + ellipsis}
+	case OverflowWrap:
+		return hardWrap(s, width)
+	case OverflowWordWrap:
+		return wordWrap(s, width)
+	}
+	return []string{truncateANSI(s, width)}
+}
+
+// hardWrap breaks s every width columns
+func hardWrap(s string, width int) []string {
+	var lines []string
+	for ansiWidth(s) > width {
+		head, tail := splitAtWidth(s, width)
+		if head == "" {
+			break // a single character wider than width
+		}
+		lines = append(lines, head)
+		s = tail
+	}
+	return append(lines, s)
+}
+
+// wordWrap breaks s between words, words longer than width are broken after a URL separator when possible
+func wordWrap(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if ansiWidth(candidate) <= width {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = word
+		for ansiWidth(line) > width {
+			head, tail := breakWord(line, width)
+			if head == "" {
+				break
+			}
+			lines = append(lines, head)
+			line = tail
+		}
+	}
+	return append(lines, line)
+}
+
+// breakWord splits a word wider than width, preferring the last '/', '?', '&', '-' or '.' that fits
+func breakWord(word string, width int) (head, tail string) {
+	head, tail = splitAtWidth(word, width)
+	if idx := strings.LastIndexAny(head, "/?&-."); idx > 0 && idx < len(head)-1 {
+		return word[:idx+1], word[idx+1:]
+	}
+	return head, tail
+}
+
+// splitAtWidth splits s after the last grapheme cluster that fits in width columns, escape sequences
+// stay in the head
+func splitAtWidth(s string, width int) (head, tail string) {
+	visible := 0
+	idx := 0
+	for idx < len(s) {
+		if n := ansiSequenceLen(s[idx:]); n > 0 {
+			idx += n
+			continue
+		}
+		size, clusterWidth := nextGrapheme(s[idx:])
+		if visible+clusterWidth > width {
+			break
+		}
+		visible += clusterWidth
+		idx += size
+	}
+	return s[:idx], s[idx:]
+}
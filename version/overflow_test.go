@@ -0,0 +1,111 @@
+package version
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFitText(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		overflow Overflow
+		want     []string
+	}{
+		{name: "fits", s: "short", width: 10, overflow: OverflowWordWrap, want: []string{"short"}},
+		{name: "unlimited", s: "a long line of text", width: 0, overflow: OverflowEllipsis, want: []string{"a long line of text"}},
+		{name: "clip", s: "Copyright 2025 Acme", width: 12, want: []string{"Copyright 20"}},
+		{name: "ellipsis", s: "Copyright 2025 Acme", width: 12, overflow: OverflowEllipsis, want: []string{"Copyright 2…"}},
+		{name: "hard wrap", s: "Copyright 2025 Acme", width: 8, overflow: OverflowWrap, want: []string{"Copyrigh", "t 2025 A", "cme"}},
+		{name: "hard wrap wide characters", s: "北京科技有限", width: 5, overflow: OverflowWrap, want: []string{"北京", "科技", "有限"}},
+		{
+			name:     "word wrap",
+			s:        "Copyright 2025 Acme Corporation, all rights reserved",
+			width:    20,
+			overflow: OverflowWordWrap,
+			want:     []string{"Copyright 2025 Acme", "Corporation, all", "rights reserved"},
+		},
+		{
+			name:     "word wrap keeps urls whole",
+			s:        "see https://github.com/acme/tool for docs",
+			width:    30,
+			overflow: OverflowWordWrap,
+			want:     []string{"see", "https://github.com/acme/tool", "for docs"},
+		},
+		{
+			name:     "word wrap breaks long urls after a separator",
+			s:        "https://github.com/acme/very-long-repository-name",
+			width:    30,
+			overflow: OverflowWordWrap,
+			want:     []string{"https://github.com/acme/very-", "long-repository-name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitText(tt.s, tt.width, tt.overflow)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fitText(%q, %d, %q) = %q, want %q", tt.s, tt.width, tt.overflow, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOverflow(t *testing.T) {
+	for _, name := range []string{"clip", "Ellipsis", " wrap ", "word-wrap"} {
+		if _, err := ParseOverflow(name); err != nil {
+			t.Errorf("ParseOverflow(%q) unexpected error: %v", name, err)
+		}
+	}
+	if _, err := ParseOverflow("scroll"); err == nil {
+		t.Errorf("ParseOverflow(\"scroll\") expected an error")
+	}
+}
+
+func TestBannerOverflow(t *testing.T) {
+	info := &Info{Major: 1, Copyright: "2025 Acme Corporation, all rights reserved", Repo: "https://github.com/acme/tool"}
+
+	t.Run("word wrap with hanging indent", func(t *testing.T) {
+		got := BannerWithOptions("App", info, BannerOptions{FixedWidth: 40, Overflow: OverflowWordWrap})
+		want := strings.Join([]string{
+			"* Copyright: 2025 Acme Corporation,    *",
+			"*            all rights reserved       *",
+			"* Repo:      https://github.com/acme/  *",
+			"*            tool                      *",
+		}, "\n")
+		if !strings.Contains(got, want) {
+			t.Errorf("banner is missing\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("ellipsis", func(t *testing.T) {
+		got := BannerWithOptions("App", info, BannerOptions{FixedWidth: 40, Overflow: OverflowEllipsis})
+		if line := "* Copyright: 2025 Acme Corporation, a… *"; !strings.Contains(got, line) {
+			t.Errorf("banner is missing %q:\n%s", line, got)
+		}
+	})
+
+	t.Run("border-less with max width", func(t *testing.T) {
+		got := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, MaxWidth: 30, Overflow: OverflowWordWrap, Notices: []string{"Updated from v0.9.0, see the changelog for details"}})
+		for _, line := range strings.Split(got, "\n") {
+			if ansiWidth(line) > 30 {
+				t.Errorf("line %q is wider than 30 columns:\n%s", line, got)
+			}
+		}
+		if !strings.Contains(got, "Copyright: 2025 Acme\n           Corporation, all\n           rights reserved\n") {
+			t.Errorf("copyright should wrap under the value column:\n%s", got)
+		}
+	})
+
+	t.Run("auto width box is capped", func(t *testing.T) {
+		showBorder := true
+		got := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, ShowBorder: &showBorder, MaxWidth: 36, Overflow: OverflowWrap})
+		for i, line := range strings.Split(got, "\n") {
+			if ansiWidth(line) != 36 {
+				t.Errorf("line %d width = %d, want 36:\n%s", i, ansiWidth(line), got)
+			}
+		}
+	})
+}
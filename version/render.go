@@ -0,0 +1,229 @@
+package version
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BannerRenderer draws a banner layout in an output format
+type BannerRenderer interface {
+	Render(layout *BannerLayout) string
+}
+
+// bannerRenderers maps the renderer names accepted by RendererByName
+var bannerRenderers = map[string]BannerRenderer{
+	"text":           TextRenderer{},
+	"markdown":       MarkdownRenderer{},
+	"markdown-table": MarkdownRenderer{Table: true},
+	"html":           HTMLRenderer{},
+	"svg":            SVGRenderer{},
+}
+
+// RendererByName returns a renderer with its default settings: text, markdown, markdown-table, html or svg
+func RendererByName(name string) (BannerRenderer, error) {
+	renderer, ok := bannerRenderers[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(bannerRenderers))
+		for n := range bannerRenderers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("invalid banner renderer: %s (expected: %s)", name, strings.Join(names, ", "))
+	}
+	return renderer, nil
+}
+
+// RenderBanner lays out the banner with opts and draws it with renderer
+func RenderBanner(appName string, info *Info, opts BannerOptions, renderer BannerRenderer) string {
+	return renderer.Render(LayoutBanner(appName, info, opts))
+}
+
+// TextRenderer draws the banner as terminal text, colored with ANSI escape sequences by the theme
+type TextRenderer struct{}
+
+// Render implements BannerRenderer
+func (TextRenderer) Render(layout *BannerLayout) string {
+	lines := make([]string, len(layout.Lines))
+	for idx, line := range layout.Lines {
+		var b strings.Builder
+		for _, span := range line.Spans {
+			b.WriteString(layout.Theme.paint(layout.Color(span.Role), span.Text))
+		}
+		lines[idx] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MarkdownRenderer draws the banner as a fenced code block, or as a table of the version, notices and metadata
+type MarkdownRenderer struct {
+	// Table renders a "| Field | Value |" table instead of the text banner
+	Table bool
+}
+
+// Render implements BannerRenderer
+func (r MarkdownRenderer) Render(layout *BannerLayout) string {
+	if r.Table {
+		return markdownTable(layout)
+	}
+
+	text := stripANSI(layout.Text())
+	fence := strings.Repeat("`", max(longestRun(text, '`')+1, 3))
+	return fence + "\n" + text + "\n" + fence
+}
+
+// markdownTable lists the name, version, notices and metadata of the layout as table rows
+func markdownTable(layout *BannerLayout) string {
+	rows := [][2]string{{"Name", strings.Join(strings.Fields(layout.AppName), " ")}}
+	if layout.Info != nil {
+		rows = append(rows, [2]string{"Version", formatVersionLine(layout.Info)})
+	}
+	for _, notice := range layout.Notices {
+		rows = append(rows, [2]string{"Notice", notice})
+	}
+	for _, field := range layout.Metadata {
+		rows = append(rows, [2]string{field.Key, field.Value})
+	}
+
+	lines := []string{"| Field | Value |", "| --- | --- |"}
+	for _, row := range rows {
+		lines = append(lines, "| "+markdownCell(row[0])+" | "+markdownCell(row[1])+" |")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownCell escapes the characters that would break a table cell
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(stripANSI(s)), " ")
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`).Replace(s)
+}
+
+// longestRun returns the length of the longest run of c in s
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for idx := 0; idx < len(s); idx++ {
+		if s[idx] != c {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// HTMLRenderer draws the banner as an escaped <pre> block
+// Each line is a span with the classes banner-line and banner-section-<section>, e.g. banner-section-metadata,
+// and the colored parts inside it are spans with the class banner-role-<role>, e.g. banner-role-label, styled
+// with the theme colors. Sections and roles have separate classes so a stylesheet can target either.
+type HTMLRenderer struct {
+	// ClassPrefix prefixes the CSS classes (defaults to "banner")
+	ClassPrefix string
+}
+
+// Render implements BannerRenderer
+func (r HTMLRenderer) Render(layout *BannerLayout) string {
+	prefix := r.ClassPrefix
+	if prefix == "" {
+		prefix = "banner"
+	}
+	prefix = html.EscapeString(prefix)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<pre class="%s">`, prefix)
+	for idx, line := range layout.Lines {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, `<span class="%[1]s-line %[1]s-section-%[2]s">`, prefix, line.Section)
+		for _, span := range line.Spans {
+			text := html.EscapeString(stripANSI(span.Text))
+			if span.Role == RolePlain {
+				b.WriteString(text)
+				continue
+			}
+			fmt.Fprintf(&b, `<span class="%s-role-%s"`, prefix, span.Role)
+			if color := layout.Color(span.Role).hex(); color != "" {
+				fmt.Fprintf(&b, ` style="color: %s"`, color)
+			}
+			b.WriteString(">" + text + "</span>")
+		}
+		b.WriteString("</span>")
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
+
+// SVGRenderer draws the banner as a standalone SVG image of monospace text, colored by the theme
+type SVGRenderer struct {
+	// FontSize is the text size in pixels (defaults to 14)
+	FontSize int
+	// FontFamily is the font list of the text (defaults to "ui-monospace, Menlo, Consolas, monospace")
+	FontFamily string
+	// Background fills the image (defaults to "#1e1e1e")
+	Background Color
+	// Foreground colors the text without a theme color (defaults to "#d4d4d4")
+	Foreground Color
+}
+
+// Render implements BannerRenderer
+func (r SVGRenderer) Render(layout *BannerLayout) string {
+	fontSize := float64(r.FontSize)
+	if r.FontSize <= 0 {
+		fontSize = 14
+	}
+	fontFamily := r.FontFamily
+	if fontFamily == "" {
+		fontFamily = "ui-monospace, Menlo, Consolas, monospace"
+	}
+	background := r.Background.hex()
+	if background == "" {
+		background = "#1e1e1e"
+	}
+	foreground := r.Foreground.hex()
+	if foreground == "" {
+		foreground = "#d4d4d4"
+	}
+
+	// Monospace glyphs are about 0.6em wide, the image keeps a margin of one line around the text
+	charWidth, lineHeight, margin := fontSize*0.6, fontSize*1.25, fontSize
+	columns := 0
+	for _, line := range layout.Lines {
+		columns = max(columns, line.width())
+	}
+	width := 2*margin + float64(columns)*charWidth
+	height := 2*margin + float64(len(layout.Lines))*lineHeight
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`,
+		svgNumber(width), svgNumber(height))
+	fmt.Fprintf(&b, "\n"+`<rect width="100%%" height="100%%" fill="%s"/>`, background)
+	fmt.Fprintf(&b, "\n"+`<g font-family="%s" font-size="%s" fill="%s">`,
+		html.EscapeString(fontFamily), svgNumber(fontSize), foreground)
+	for idx, line := range layout.Lines {
+		if strings.TrimSpace(stripANSI(line.Text())) == "" {
+			continue
+		}
+		y := margin + float64(idx)*lineHeight + fontSize
+		fmt.Fprintf(&b, "\n"+`<text x="%s" y="%s" xml:space="preserve">`, svgNumber(margin), svgNumber(y))
+		for _, span := range line.Spans {
+			text := html.EscapeString(stripANSI(span.Text))
+			if color := layout.Color(span.Role).hex(); color != "" {
+				fmt.Fprintf(&b, `<tspan fill="%s">%s</tspan>`, color, text)
+			} else {
+				b.WriteString(text)
+			}
+		}
+		b.WriteString("</text>")
+	}
+	b.WriteString("\n</g>\n</svg>")
+	return b.String()
+}
+
+// svgNumber formats a coordinate with at most two decimals
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,169 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutBannerSections(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Suffix: "beta", Author: "Jane"}
+	showBorder := true
+	layout := LayoutBanner("App", info, BannerOptions{AutoWidth: true, ShowBorder: &showBorder, Notices: []string{"Deprecated"}})
+
+	var sections []string
+	for _, line := range layout.Lines {
+		sections = append(sections, string(line.Section))
+	}
+	want := "border blank title blank version notice blank metadata blank border"
+	if got := strings.Join(sections, " "); got != want {
+		t.Errorf("sections = %q, want %q", got, want)
+	}
+
+	version := layout.Lines[4]
+	var roles []string
+	for _, span := range version.Spans {
+		roles = append(roles, string(span.Role)+":"+strings.TrimSpace(span.Text))
+	}
+	if got, want := strings.Join(roles, " "), "border:* : : version:v1.2.3 : suffix:[BETA] : : border:*"; got != want {
+		t.Errorf("version spans = %q, want %q", got, want)
+	}
+	if got, want := layout.Text(), BannerWithOptions("App", info, BannerOptions{AutoWidth: true, ShowBorder: &showBorder, Notices: []string{"Deprecated"}}); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTextRendererMatchesBanner(t *testing.T) {
+	info := &Info{Major: 2, Minor: 0, Patch: 1, Hash: "abc", Suffix: "rc", Repo: "github.com/acme/app"}
+	theme := DefaultTheme()
+	theme.Profile = ColorProfileANSI256
+	opts := BannerOptions{FixedWidth: 40, Theme: theme, Dividers: true, Margin: BannerMargin{Left: 2}}
+
+	if got, want := RenderBanner("App", info, opts, TextRenderer{}), BannerWithOptions("App", info, opts); got != want {
+		t.Errorf("RenderBanner() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Suffix: "beta", Author: "Jane | Doe"}
+	opts := BannerOptions{AutoWidth: true, Notices: []string{"Use `app2` instead"}}
+
+	t.Run("fenced", func(t *testing.T) {
+		got := RenderBanner("App", info, opts, MarkdownRenderer{})
+		want := "```\nApp\n\nv1.2.3 [BETA]\nUse `app2` instead\n\nAuthor:    Jane | Doe\n\n```"
+		if got != want {
+			t.Errorf("Render() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("longer fence around backticks", func(t *testing.T) {
+		got := RenderBanner("```App```", info, BannerOptions{AutoWidth: true}, MarkdownRenderer{})
+		if !strings.HasPrefix(got, "````\n") || !strings.HasSuffix(got, "\n````") {
+			t.Errorf("Render() = %q, want a four backtick fence", got)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		got := RenderBanner("My\nApp", info, opts, MarkdownRenderer{Table: true})
+		want := strings.Join([]string{
+			"| Field | Value |",
+			"| --- | --- |",
+			"| Name | My App |",
+			"| Version | v1.2.3 [BETA] |",
+			"| Notice | Use `app2` instead |",
+			`| Author | Jane \| Doe |`,
+		}, "\n")
+		if got != want {
+			t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	info := &Info{Major: 1, Minor: 0, Patch: 0, Suffix: "dev", Author: "<Jane & Co>"}
+	theme := &Theme{Border: "cyan", Label: "#FF8800", Suffix: map[Stability]Color{StabilityDev: "red"}, Profile: ColorProfileNone}
+	showBorder := true
+	opts := BannerOptions{AutoWidth: true, ShowBorder: &showBorder, Theme: theme}
+
+	got := RenderBanner("App", info, opts, HTMLRenderer{})
+	for _, want := range []string{
+		`<pre class="banner"><span class="banner-line banner-section-border"><span class="banner-role-border" style="color: #00cdcd">***`,
+		`<span class="banner-line banner-section-title"><span class="banner-role-border" style="color: #00cdcd">*</span>`,
+		`<span class="banner-role-suffix" style="color: #cd0000">[DEV]</span>`,
+		`<span class="banner-role-version">v1.0.0</span>`,
+		`<span class="banner-role-label" style="color: #ff8800">Author:</span>    <span class="banner-role-value">&lt;Jane &amp; Co&gt;</span>`,
+		"</span></pre>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() = %s\nwant it to contain %s", got, want)
+		}
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("Render() contains escape sequences: %q", got)
+	}
+
+	got = RenderBanner("App", info, BannerOptions{AutoWidth: true}, HTMLRenderer{ClassPrefix: "ver"})
+	if !strings.HasPrefix(got, `<pre class="ver"><span class="ver-line ver-section-title"><span class="ver-role-title">App</span></span>`) {
+		t.Errorf("Render() = %s, want the ver class prefix", got)
+	}
+}
+
+func TestSVGRenderer(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Suffix: "beta"}
+	theme := &Theme{Title: "bright-white", Suffix: map[Stability]Color{StabilityBeta: "yellow"}}
+	got := RenderBanner("A&B", info, BannerOptions{AutoWidth: true, Theme: theme}, SVGRenderer{FontSize: 10, Background: "#000"})
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="98" height="70" viewBox="0 0 98 70">`,
+		`<rect width="100%" height="100%" fill="#000000"/>`,
+		`<g font-family="ui-monospace, Menlo, Consolas, monospace" font-size="10" fill="#d4d4d4">`,
+		`<text x="10" y="20" xml:space="preserve"><tspan fill="#ffffff">A&amp;B</tspan></text>`,
+		`<text x="10" y="45" xml:space="preserve">v1.2.3 <tspan fill="#cdcd00">[BETA]</tspan></text>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() = %s\nwant it to contain %s", got, want)
+		}
+	}
+	if lines := strings.Count(got, "<text "); lines != 2 {
+		t.Errorf("Render() has %d text elements, want 2 (blank lines are skipped)", lines)
+	}
+	if !strings.HasSuffix(got, "</svg>") {
+		t.Errorf("Render() = %s, want it to end with </svg>", got)
+	}
+}
+
+func TestRendererByName(t *testing.T) {
+	for _, name := range []string{"text", "Markdown", "markdown-table", "html", " svg "} {
+		if _, err := RendererByName(name); err != nil {
+			t.Errorf("RendererByName(%q) error = %v", name, err)
+		}
+	}
+
+	_, err := RendererByName("pdf")
+	if err == nil {
+		t.Fatal("RendererByName(pdf) error = nil, want error")
+	}
+	if want := "invalid banner renderer: pdf (expected: html, markdown, markdown-table, svg, text)"; err.Error() != want {
+		t.Errorf("RendererByName(pdf) error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestColorHex(t *testing.T) {
+	tests := []struct {
+		color Color
+		want  string
+	}{
+		{"", ""},
+		{"red", "#cd0000"},
+		{"Bright-Blue", "#5c5cff"},
+		{"208", "#ff8700"},
+		{"244", "#808080"},
+		{"#abc", "#aabbcc"},
+		{"#FF8800", "#ff8800"},
+		{"nope", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.color.hex(); got != tt.want {
+			t.Errorf("Color(%q).hex() = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
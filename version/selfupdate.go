@@ -0,0 +1,408 @@
+package version
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	defaultBackupSuffix = ".old"
+	maxAssetSize        = 1 << 30
+)
+
+// ErrNoAsset is returned when the newest release has no asset for the target platform
+var ErrNoAsset = errors.New("release has no asset for this platform")
+
+// lockedExecutables reports whether the running executable cannot be replaced in place, as on Windows,
+// where it can only be renamed before the new binary takes its path
+var lockedExecutables = runtime.GOOS == "windows"
+
+// renameFile moves files during an update, replaced in tests to mimic a locked executable
+var renameFile = os.Rename
+
+// SignedAssetMessage returns the message signed for an asset: its release version, GOOS, GOARCH and
+// hex encoded SHA-256 checksum joined by "|", e.g. "1.5.0|linux|amd64|9f86d0...", so that a signature
+// cannot be replayed for another release or platform
+func SignedAssetMessage(version, goos, goarch, sha256Hex string) []byte {
+	return []byte(strings.Join([]string{version, goos, goarch, strings.ToLower(sha256Hex)}, "|"))
+}
+
+// ManifestReleaseSource reads releases from a ReleaseFeed JSON manifest served over HTTP
+type ManifestReleaseSource struct {
+	URL string
+	// Client is the HTTP client to use (defaults to http.DefaultClient)
+	Client *http.Client
+}
+
+// Releases implements ReleaseSource
+func (s *ManifestReleaseSource) Releases(ctx context.Context) ([]Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var feed ReleaseFeed
+	if err := getJSON(s.Client, req, &feed); err != nil {
+		return nil, err
+	}
+	return feed.Releases, nil
+}
+
+// SelfUpdater replaces the running executable with the newest release asset for its platform
+// The download is verified against the asset SHA-256 checksum and, when PublicKey is set,
+// its ed25519 signature of SignedAssetMessage before the executable is swapped; the previous binary is kept as a rollback copy.
+type SelfUpdater struct {
+	// Source lists the releases and their assets
+	Source ReleaseSource
+	// Current is the running version
+	Current *Info
+	// Channel is the least stable release accepted (defaults to the stability of Current)
+	Channel Stability
+	// Client downloads the assets (defaults to http.DefaultClient)
+	Client *http.Client
+	// PublicKey, when set, requires every asset to carry a valid ed25519 signature of its SignedAssetMessage
+	PublicKey ed25519.PublicKey
+	// Executable is the file to replace (defaults to os.Executable with symlinks resolved)
+	Executable string
+	// GOOS and GOARCH select the asset (default to runtime.GOOS and runtime.GOARCH)
+	GOOS   string
+	GOARCH string
+	// BackupSuffix is appended to Executable for the rollback copy (defaults to ".old")
+	BackupSuffix string
+}
+
+// SelfUpdate replaces the running executable with the newest release from source, if it is newer than current
+// It returns the installed release, or nil when already up to date.
+func SelfUpdate(ctx context.Context, source ReleaseSource, current *Info) (*Release, error) {
+	updater := &SelfUpdater{Source: source, Current: current}
+	return updater.Update(ctx)
+}
+
+// Update installs the newest release on the channel, returning nil when already up to date
+func (u *SelfUpdater) Update(ctx context.Context) (*Release, error) {
+	if u.Source == nil || u.Current == nil {
+		return nil, errors.New("self updater needs a source and the current version")
+	}
+
+	channel := u.Channel
+	if channel == "" {
+		channel = u.Current.Stability()
+	}
+
+	releases, err := u.Source.Releases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list releases: %w", err)
+	}
+
+	latest, latestInfo := latestRelease(releases, channel)
+	if latest == nil || Compare(latestInfo, u.Current) <= 0 {
+		return nil, nil
+	}
+
+	asset, err := u.findAsset(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := u.download(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.verify(latest, asset, content); err != nil {
+		return nil, err
+	}
+
+	executable, err := u.executable()
+	if err != nil {
+		return nil, err
+	}
+	if err := replaceExecutable(executable, executable+u.backupSuffix(), content); err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// Rollback restores the rollback copy kept by the last Update
+func (u *SelfUpdater) Rollback() error {
+	executable, err := u.executable()
+	if err != nil {
+		return err
+	}
+
+	backup := executable + u.backupSuffix()
+	if _, err := os.Stat(backup); err != nil {
+		return fmt.Errorf("no rollback copy available: %w", err)
+	}
+	if !lockedExecutables {
+		return renameFile(backup, executable)
+	}
+
+	// The running executable is moved aside first, and removed once it is no longer running
+	discarded := executable + ".discard"
+	_ = os.Remove(discarded)
+	if err := renameFile(executable, discarded); err != nil {
+		return fmt.Errorf("cannot move the running executable aside: %w", err)
+	}
+	if err := renameFile(backup, executable); err != nil {
+		_ = renameFile(discarded, executable)
+		return err
+	}
+	_ = os.Remove(discarded)
+	return nil
+}
+
+// platform returns the target GOOS and GOARCH
+func (u *SelfUpdater) platform() (string, string) {
+	goos, goarch := u.GOOS, u.GOARCH
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return goos, goarch
+}
+
+// findAsset returns the asset matching the target platform, by its OS/Arch fields or by name
+func (u *SelfUpdater) findAsset(release *Release) (*Asset, error) {
+	goos, goarch := u.platform()
+
+	var archive string
+	for idx := range release.Assets {
+		asset := &release.Assets[idx]
+		matches := asset.OS == goos && asset.Arch == goarch
+		if asset.OS == "" && asset.Arch == "" {
+			matches = hasNameTokens(asset.Name, goos, goarch)
+		}
+		if !matches {
+			continue
+		}
+		if isArchive(asset.Name) {
+			archive = asset.Name
+			continue
+		}
+		return asset, nil
+	}
+
+	if archive != "" {
+		return nil, fmt.Errorf("%w: %s %s/%s is only published as archive %s", ErrNoAsset, release.Version, goos, goarch, archive)
+	}
+	return nil, fmt.Errorf("%w: %s %s/%s", ErrNoAsset, release.Version, goos, goarch)
+}
+
+// hasNameTokens reports whether the asset name contains every token as a whole word between
+// '_', '-' and '.' separators, so that "arm" does not match "tool_linux_arm64"
+func hasNameTokens(name string, tokens ...string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	})
+	for _, token := range tokens {
+		found := false
+		for _, word := range words {
+			if word == strings.ToLower(token) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// archiveExtensions are the asset extensions that cannot be installed as an executable as is
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar.xz", ".tar.bz2", ".tar", ".zip"}
+
+// isArchive reports whether the asset name has an archive extension
+func isArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// download fetches the asset content
+func (u *SelfUpdater) download(ctx context.Context, asset *Asset) ([]byte, error) {
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download %s: %w", asset.Name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download %s: %s", asset.Name, resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot download %s: %w", asset.Name, err)
+	}
+	if len(content) > maxAssetSize {
+		return nil, fmt.Errorf("asset %s exceeds %d bytes", asset.Name, maxAssetSize)
+	}
+	if asset.Size > 0 && int64(len(content)) != asset.Size {
+		return nil, fmt.Errorf("asset %s size mismatch: got %d bytes, want %d", asset.Name, len(content), asset.Size)
+	}
+	return content, nil
+}
+
+// verify checks the asset checksum and, when a public key is configured, its signature of the release
+// version, target platform and checksum
+func (u *SelfUpdater) verify(release *Release, asset *Asset, content []byte) error {
+	if asset.SHA256 == "" {
+		return fmt.Errorf("asset %s has no sha256 checksum", asset.Name)
+	}
+	want, err := hex.DecodeString(strings.TrimSpace(asset.SHA256))
+	if err != nil {
+		return fmt.Errorf("asset %s has an invalid sha256 checksum: %w", asset.Name, err)
+	}
+	sum := sha256.Sum256(content)
+	if !bytes.Equal(sum[:], want) {
+		return fmt.Errorf("asset %s checksum mismatch: got %x, want %s", asset.Name, sum, asset.SHA256)
+	}
+
+	if len(u.PublicKey) == 0 {
+		return nil
+	}
+	if len(u.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid ed25519 public key size %d", len(u.PublicKey))
+	}
+	if asset.Signature == "" {
+		return fmt.Errorf("asset %s is not signed", asset.Name)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(asset.Signature))
+	if err != nil {
+		return fmt.Errorf("asset %s has an invalid signature encoding: %w", asset.Name, err)
+	}
+	goos, goarch := u.platform()
+	message := SignedAssetMessage(release.Version, goos, goarch, hex.EncodeToString(sum[:]))
+	if !ed25519.Verify(u.PublicKey, message, signature) {
+		return fmt.Errorf("asset %s signature verification failed", asset.Name)
+	}
+	return nil
+}
+
+// executable returns the path of the file to replace
+func (u *SelfUpdater) executable() (string, error) {
+	if u.Executable != "" {
+		return u.Executable, nil
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate the running executable: %w", err)
+	}
+	return filepath.EvalSymlinks(executable)
+}
+
+func (u *SelfUpdater) backupSuffix() string {
+	if u.BackupSuffix == "" {
+		return defaultBackupSuffix
+	}
+	return u.BackupSuffix
+}
+
+// replaceExecutable writes content next to executable and swaps it in with a single rename,
+// after keeping the current binary as backup, so that the executable path always exists.
+// Where the running executable is locked, it is renamed to backup before the new binary is moved in.
+func replaceExecutable(executable, backup string, content []byte) error {
+	stat, err := os.Stat(executable)
+	if err != nil {
+		return fmt.Errorf("cannot stat executable: %w", err)
+	}
+
+	dir := filepath.Dir(executable)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(executable)+".*.new")
+	if err != nil {
+		return fmt.Errorf("cannot stage update: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cannot stage update: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cannot stage update: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot stage update: %w", err)
+	}
+	if err := os.Chmod(tmpName, stat.Mode().Perm()); err != nil {
+		return fmt.Errorf("cannot stage update: %w", err)
+	}
+
+	_ = os.Remove(backup)
+	if lockedExecutables {
+		if err := renameFile(executable, backup); err != nil {
+			return fmt.Errorf("cannot keep rollback copy: %w", err)
+		}
+		if err := renameFile(tmpName, executable); err != nil {
+			_ = renameFile(backup, executable)
+			return fmt.Errorf("cannot install update: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Link(executable, backup); err != nil {
+		if err := copyFile(executable, backup, stat.Mode().Perm()); err != nil {
+			return fmt.Errorf("cannot keep rollback copy: %w", err)
+		}
+	}
+	if err := renameFile(tmpName, executable); err != nil {
+		return fmt.Errorf("cannot install update: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst with perm, used when dst cannot be a hard link to src
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+package version
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// selfUpdateFixture serves a manifest with one release asset and holds a temporary executable
+type selfUpdateFixture struct {
+	server     *httptest.Server
+	executable string
+	newBinary  []byte
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+	feed       ReleaseFeed
+}
+
+// newSelfUpdateFixture publishes newBinary as versions 1.5.0 and 1.6.0-beta for linux/amd64, letting mutate adjust the assets
+func newSelfUpdateFixture(t *testing.T, mutate func(asset *Asset)) *selfUpdateFixture {
+	t.Helper()
+	f := &selfUpdateFixture{newBinary: []byte("#!/bin/sh\necho 1.5.0\n")}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	f.publicKey, f.privateKey = publicKey, privateKey
+
+	mux := http.NewServeMux()
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	asset := func(version string) Asset {
+		sum := sha256.Sum256(f.newBinary)
+		asset := Asset{
+			Name:      "tool_linux_amd64",
+			URL:       f.server.URL + "/download/tool_linux_amd64",
+			Size:      int64(len(f.newBinary)),
+			OS:        "linux",
+			Arch:      "amd64",
+			SHA256:    hex.EncodeToString(sum[:]),
+			Signature: f.sign(version, "linux", "amd64"),
+		}
+		if mutate != nil {
+			mutate(&asset)
+		}
+		return asset
+	}
+	f.feed = ReleaseFeed{Releases: []Release{
+		{Version: "1.4.0"},
+		{Version: "1.5.0", Assets: []Asset{{Name: "tool_darwin_arm64", URL: f.server.URL + "/nope", OS: "darwin", Arch: "arm64"}, asset("1.5.0")}},
+		{Version: "1.6.0-beta", Assets: []Asset{asset("1.6.0-beta")}},
+	}}
+
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(f.feed)
+	})
+	mux.HandleFunc("/download/tool_linux_amd64", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(f.newBinary)
+	})
+
+	f.executable = filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(f.executable, []byte("old binary"), 0o700); err != nil { // #nosec G306 -- test executable
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return f
+}
+
+// sign returns the encoded signature of newBinary published as version for goos/goarch
+func (f *selfUpdateFixture) sign(version, goos, goarch string) string {
+	sum := sha256.Sum256(f.newBinary)
+	message := SignedAssetMessage(version, goos, goarch, hex.EncodeToString(sum[:]))
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(f.privateKey, message))
+}
+
+// updater returns a SelfUpdater for the fixture running the given version
+func (f *selfUpdateFixture) updater(t *testing.T, current string) *SelfUpdater {
+	return &SelfUpdater{
+		Source:     &ManifestReleaseSource{URL: f.server.URL + "/manifest.json", Client: f.server.Client()},
+		Current:    mustParse(t, current),
+		Client:     f.server.Client(),
+		PublicKey:  f.publicKey,
+		Executable: f.executable,
+		GOOS:       "linux",
+		GOARCH:     "amd64",
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", path, err)
+	}
+	return string(content)
+}
+
+func TestSelfUpdateReplacesExecutable(t *testing.T) {
+	f := newSelfUpdateFixture(t, nil)
+	updater := f.updater(t, "1.4.0")
+
+	release, err := updater.Update(context.Background())
+	if err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if release == nil || release.Version != "1.5.0" {
+		t.Fatalf("Update() = %+v, want release 1.5.0", release)
+	}
+
+	if got := readFile(t, f.executable); got != string(f.newBinary) {
+		t.Errorf("executable content = %q, want new binary", got)
+	}
+	if got := readFile(t, f.executable+".old"); got != "old binary" {
+		t.Errorf("rollback copy = %q, want old binary", got)
+	}
+	if stat, _ := os.Stat(f.executable); stat.Mode().Perm() != 0o700 {
+		t.Errorf("executable mode = %v, want 0700 kept", stat.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(f.executable))
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want executable and rollback copy only", len(entries))
+	}
+
+	if err := updater.Rollback(); err != nil {
+		t.Fatalf("Rollback() unexpected error: %v", err)
+	}
+	if got := readFile(t, f.executable); got != "old binary" {
+		t.Errorf("executable after rollback = %q, want old binary", got)
+	}
+	if err := updater.Rollback(); err == nil {
+		t.Errorf("second Rollback() expected error but got none")
+	}
+}
+
+func TestSelfUpdateUpToDate(t *testing.T) {
+	f := newSelfUpdateFixture(t, nil)
+
+	release, err := f.updater(t, "1.5.0").Update(context.Background())
+	if err != nil || release != nil {
+		t.Fatalf("Update() = %+v, %v, want nil release and no error", release, err)
+	}
+	if got := readFile(t, f.executable); got != "old binary" {
+		t.Errorf("executable content = %q, want untouched", got)
+	}
+
+	// A beta build follows the beta channel
+	release, err = f.updater(t, "1.5.0-beta").Update(context.Background())
+	if err != nil || release == nil || release.Version != "1.6.0-beta" {
+		t.Fatalf("Update() = %+v, %v, want 1.6.0-beta", release, err)
+	}
+}
+
+func TestSelfUpdateVerificationFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(asset *Asset)
+		goarch  string
+		wantErr string
+	}{
+		{
+			name:    "checksum mismatch",
+			mutate:  func(asset *Asset) { asset.SHA256 = strings.Repeat("00", sha256.Size) },
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "missing checksum",
+			mutate:  func(asset *Asset) { asset.SHA256 = "" },
+			wantErr: "no sha256 checksum",
+		},
+		{
+			name: "invalid signature",
+			mutate: func(asset *Asset) {
+				asset.Signature = base64.StdEncoding.EncodeToString(make([]byte, ed25519.SignatureSize))
+			},
+			wantErr: "signature verification failed",
+		},
+		{
+			name:    "missing signature",
+			mutate:  func(asset *Asset) { asset.Signature = "" },
+			wantErr: "not signed",
+		},
+		{
+			name:    "size mismatch",
+			mutate:  func(asset *Asset) { asset.Size++ },
+			wantErr: "size mismatch",
+		},
+		{
+			name:    "no asset for platform",
+			goarch:  "riscv64",
+			wantErr: ErrNoAsset.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSelfUpdateFixture(t, tt.mutate)
+			updater := f.updater(t, "1.4.0")
+			if tt.goarch != "" {
+				updater.GOARCH = tt.goarch
+			}
+
+			_, err := updater.Update(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Update() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if got := readFile(t, f.executable); got != "old binary" {
+				t.Errorf("executable content = %q, want untouched", got)
+			}
+			if _, err := os.Stat(f.executable + ".old"); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("rollback copy exists after failed update")
+			}
+		})
+	}
+}
+
+func TestSelfUpdateRejectsReplayedSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature func(f *selfUpdateFixture) string
+	}{
+		{
+			name:      "signed for another release",
+			signature: func(f *selfUpdateFixture) string { return f.sign("1.6.0-beta", "linux", "amd64") },
+		},
+		{
+			name:      "signed for another platform",
+			signature: func(f *selfUpdateFixture) string { return f.sign("1.5.0", "darwin", "arm64") },
+		},
+		{
+			name: "signed content only",
+			signature: func(f *selfUpdateFixture) string {
+				return base64.StdEncoding.EncodeToString(ed25519.Sign(f.privateKey, f.newBinary))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSelfUpdateFixture(t, nil)
+			f.feed.Releases[1].Assets[1].Signature = tt.signature(f)
+
+			_, err := f.updater(t, "1.4.0").Update(context.Background())
+			if err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+				t.Fatalf("Update() error = %v, want a signature verification failure", err)
+			}
+			if got := readFile(t, f.executable); got != "old binary" {
+				t.Errorf("executable content = %q, want untouched", got)
+			}
+		})
+	}
+}
+
+func TestSelfUpdateLockedExecutable(t *testing.T) {
+	f := newSelfUpdateFixture(t, nil)
+	updater := f.updater(t, "1.4.0")
+
+	// Like Windows, refuse to replace the executable while it exists
+	lockedExecutables = true
+	renameFile = func(oldpath, newpath string) error {
+		if _, err := os.Stat(newpath); newpath == f.executable && err == nil {
+			return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: os.ErrPermission}
+		}
+		return os.Rename(oldpath, newpath)
+	}
+	t.Cleanup(func() {
+		lockedExecutables = runtime.GOOS == "windows"
+		renameFile = os.Rename
+	})
+
+	if _, err := updater.Update(context.Background()); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if got := readFile(t, f.executable); got != string(f.newBinary) {
+		t.Errorf("executable content = %q, want new binary", got)
+	}
+	if got := readFile(t, f.executable+".old"); got != "old binary" {
+		t.Errorf("rollback copy = %q, want old binary", got)
+	}
+
+	if err := updater.Rollback(); err != nil {
+		t.Fatalf("Rollback() unexpected error: %v", err)
+	}
+	if got := readFile(t, f.executable); got != "old binary" {
+		t.Errorf("executable after rollback = %q, want old binary", got)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(f.executable))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries after rollback, want the executable only", len(entries))
+	}
+}
+
+func TestSelfUpdateWithoutPublicKeySkipsSignature(t *testing.T) {
+	f := newSelfUpdateFixture(t, func(asset *Asset) { asset.Signature = "" })
+	updater := f.updater(t, "1.4.0")
+	updater.PublicKey = nil
+
+	if _, err := updater.Update(context.Background()); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if got := readFile(t, f.executable); got != string(f.newBinary) {
+		t.Errorf("executable content = %q, want new binary", got)
+	}
+}
+
+func TestFindAssetByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		goarch  string
+		assets  []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "case-insensitive",
+			goos:   "windows",
+			goarch: "arm64",
+			assets: []string{"tool_linux_arm64.tar.gz", "tool_Windows_ARM64.exe"},
+			want:   "tool_Windows_ARM64.exe",
+		},
+		{
+			name:   "arm does not match arm64",
+			goos:   "linux",
+			goarch: "arm",
+			assets: []string{"tool_linux_arm64", "tool-linux-arm"},
+			want:   "tool-linux-arm",
+		},
+		{
+			name:    "arm without an arm asset",
+			goos:    "linux",
+			goarch:  "arm",
+			assets:  []string{"tool_linux_arm64", "tool_linux_armv7"},
+			wantErr: "release has no asset for this platform: 1.0.0 linux/arm",
+		},
+		{
+			name:   "archives are skipped",
+			goos:   "linux",
+			goarch: "amd64",
+			assets: []string{"tool_1.0.0_linux_amd64.tar.gz", "tool_1.0.0_linux_amd64"},
+			want:   "tool_1.0.0_linux_amd64",
+		},
+		{
+			name:    "archive only",
+			goos:    "darwin",
+			goarch:  "amd64",
+			assets:  []string{"tool_darwin_amd64.zip"},
+			wantErr: "release has no asset for this platform: 1.0.0 darwin/amd64 is only published as archive tool_darwin_amd64.zip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := &Release{Version: "1.0.0"}
+			for _, name := range tt.assets {
+				release.Assets = append(release.Assets, Asset{Name: name})
+			}
+
+			updater := &SelfUpdater{GOOS: tt.goos, GOARCH: tt.goarch}
+			asset, err := updater.findAsset(release)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || !errors.Is(err, ErrNoAsset) {
+					t.Errorf("findAsset() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || asset.Name != tt.want {
+				t.Errorf("findAsset() = %+v, %v, want %s", asset, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package version
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// LogValue implements slog.LogValuer, logging the version as a group of version, hash, suffix and build_time
+// Empty fields are omitted, e.g. version.version=1.2.3 version.suffix=beta
+func (i *Info) LogValue() slog.Value {
+	if i == nil {
+		return slog.GroupValue()
+	}
+
+	attrs := []slog.Attr{
+		slog.String("version", fmt.Sprintf("%d.%d.%d", i.Major, i.Minor, i.Patch)),
+	}
+	if i.Hash != "" {
+		attrs = append(attrs, slog.String("hash", i.Hash))
+	}
+	if i.Suffix != "" {
+		attrs = append(attrs, slog.String("suffix", i.Suffix))
+	}
+	if !i.BuildTime.IsZero() {
+		attrs = append(attrs, slog.Time("build_time", i.BuildTime))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogHandler wraps h so that every record carries a "version" group describing info
+func LogHandler(h slog.Handler, info *Info) slog.Handler {
+	return h.WithAttrs([]slog.Attr{{Key: "version", Value: info.LogValue()}})
+}
+
+// Logger returns a logger adding a "version" group describing info to every record
+// A nil logger uses slog.Default()
+func Logger(logger *slog.Logger, info *Info) *slog.Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return slog.New(LogHandler(logger.Handler(), info))
+}
+
+// LogBanner logs the banner one line per record at info level instead of writing it to stdout
+func LogBanner(logger *slog.Logger, appName string, info *Info) {
+	LogBannerWithOptions(logger, appName, info, BannerOptions{
+		AutoWidth:  true,
+		FixedWidth: defaultBoxWidth,
+		FontStyle:  FontStyleSlant,
+	})
+}
+
+// LogBannerWithOptions logs the banner generated with custom options, one line per record
+// Blank lines are skipped and each record carries a "banner" attribute with the app name.
+func LogBannerWithOptions(logger *slog.Logger, appName string, info *Info, opts BannerOptions) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	name := strings.Join(strings.Fields(appName), " ")
+	for _, line := range strings.Split(BannerWithOptions(appName, info, opts), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		logger.Info(line, slog.String("banner", name))
+	}
+}
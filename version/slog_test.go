@@ -0,0 +1,108 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decodeRecords parses JSON log output into one map per record
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestInfoLogValue(t *testing.T) {
+	buildTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		info *Info
+		want map[string]any
+	}{
+		{
+			name: "all fields",
+			info: &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "beta", BuildTime: buildTime},
+			want: map[string]any{"version": "1.2.3", "hash": "ABC123", "suffix": "beta", "build_time": "2025-03-01T12:00:00Z"},
+		},
+		{
+			name: "release without metadata",
+			info: &Info{Major: 2},
+			want: map[string]any{"version": "2.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, nil)).Info("started", "app", tt.info)
+
+			records := decodeRecords(t, &buf)
+			group, ok := records[0]["app"].(map[string]any)
+			if !ok {
+				t.Fatalf("app attribute is not a group: %v", records[0]["app"])
+			}
+			if len(group) != len(tt.want) {
+				t.Errorf("group = %v, want %v", group, tt.want)
+			}
+			for key, want := range tt.want {
+				if group[key] != want {
+					t.Errorf("%s = %v, want %v", key, group[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggerAddsVersionGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Logger(slog.New(slog.NewJSONHandler(&buf, nil)), &Info{Major: 0, Minor: 3, Patch: 1, Suffix: "dev"})
+
+	logger.Info("first")
+	logger.With("request", "abc").Warn("second")
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	for _, record := range records {
+		group, ok := record["version"].(map[string]any)
+		if !ok || group["version"] != "0.3.1" || group["suffix"] != "dev" {
+			t.Errorf("record %v missing version group", record)
+		}
+	}
+}
+
+func TestLogBanner(t *testing.T) {
+	var buf bytes.Buffer
+	info := &Info{Major: 1, Minor: 0, Patch: 0, Author: "Jane"}
+
+	LogBanner(slog.New(slog.NewJSONHandler(&buf, nil)), "MyApp", info)
+
+	records := decodeRecords(t, &buf)
+	wantMessages := []string{"MyApp", "v1.0.0", "Author:    Jane"}
+	if len(records) != len(wantMessages) {
+		t.Fatalf("got %d records, want %d: %v", len(records), len(wantMessages), records)
+	}
+	for idx, record := range records {
+		if record["msg"] != wantMessages[idx] {
+			t.Errorf("record %d msg = %q, want %q", idx, record["msg"], wantMessages[idx])
+		}
+		if record["level"] != "INFO" || record["banner"] != "MyApp" {
+			t.Errorf("record %d = %v, want INFO level and banner attribute", idx, record)
+		}
+	}
+}
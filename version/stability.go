@@ -0,0 +1,84 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stability is the release channel a version belongs to, derived from its suffix
+type Stability string
+
+const (
+	// StabilityCanary - canary builds, the least stable channel
+	StabilityCanary Stability = "canary"
+	// StabilityDev - dev builds
+	StabilityDev Stability = "dev"
+	// StabilityAlpha - alpha pre-releases
+	StabilityAlpha Stability = "alpha"
+	// StabilityBeta - beta pre-releases
+	StabilityBeta Stability = "beta"
+	// StabilityRC - release candidates (rc1, rc2, ...)
+	StabilityRC Stability = "rc"
+	// StabilityStable - releases without suffix
+	StabilityStable Stability = "stable"
+)
+
+// ParseStability parses a stability name such as "beta" or "stable" (an rcN suffix maps to rc)
+func ParseStability(s string) (Stability, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case name == string(StabilityStable) || name == "release":
+		return StabilityStable, nil
+	case name == "":
+		return "", fmt.Errorf("stability cannot be empty")
+	}
+
+	stability := stabilityOf(name)
+	if stability == StabilityCanary && name != string(StabilityCanary) {
+		return "", fmt.Errorf("invalid stability: %s (expected: canary, dev, alpha, beta, rc or stable)", s)
+	}
+	return stability, nil
+}
+
+// Stability returns the channel of this version based on its suffix
+func (i *Info) Stability() Stability {
+	return stabilityOf(i.Suffix)
+}
+
+// AtLeast returns true if s is as stable as other or more, e.g. beta is at least alpha
+func (s Stability) AtLeast(other Stability) bool {
+	return s.rank() >= other.rank()
+}
+
+// rank orders stabilities from canary (0) to stable (5)
+func (s Stability) rank() int {
+	switch s {
+	case StabilityStable:
+		return 5
+	case StabilityRC:
+		return 4
+	case StabilityBeta:
+		return 3
+	case StabilityAlpha:
+		return 2
+	case StabilityDev:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// stabilityOf maps a version suffix to its stability, unknown suffixes ranking with canary as the least stable
+func stabilityOf(suffix string) Stability {
+	suffix = strings.ToLower(suffix)
+	switch {
+	case suffix == "":
+		return StabilityStable
+	case strings.HasPrefix(suffix, "rc"):
+		return StabilityRC
+	case suffix == string(StabilityBeta), suffix == string(StabilityAlpha), suffix == string(StabilityDev):
+		return Stability(suffix)
+	default:
+		return StabilityCanary
+	}
+}
@@ -0,0 +1,43 @@
+package version
+
+import "testing"
+
+func TestStability(t *testing.T) {
+	tests := []struct {
+		suffix string
+		want   Stability
+	}{
+		{suffix: "", want: StabilityStable},
+		{suffix: "rc3", want: StabilityRC},
+		{suffix: "beta", want: StabilityBeta},
+		{suffix: "alpha", want: StabilityAlpha},
+		{suffix: "dev", want: StabilityDev},
+		{suffix: "canary", want: StabilityCanary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.suffix, func(t *testing.T) {
+			if got := (&Info{Suffix: tt.suffix}).Stability(); got != tt.want {
+				t.Errorf("Stability() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if !StabilityStable.AtLeast(StabilityBeta) || StabilityDev.AtLeast(StabilityAlpha) || !StabilityRC.AtLeast(StabilityRC) {
+		t.Errorf("AtLeast() does not follow canary < dev < alpha < beta < rc < stable")
+	}
+}
+
+func TestParseStability(t *testing.T) {
+	for input, want := range map[string]Stability{"stable": StabilityStable, "Release": StabilityStable, " beta ": StabilityBeta, "rc2": StabilityRC, "canary": StabilityCanary} {
+		got, err := ParseStability(input)
+		if err != nil || got != want {
+			t.Errorf("ParseStability(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "nightly", "gamma"} {
+		if _, err := ParseStability(input); err == nil {
+			t.Errorf("ParseStability(%q) expected error but got none", input)
+		}
+	}
+}
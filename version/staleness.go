@@ -0,0 +1,185 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrBuildExpired is returned by StalenessPolicy.Enforce when the build is past its expiry
+var ErrBuildExpired = errors.New("build has expired")
+
+// readBuildInfo returns the build information embedded by the Go toolchain, tests can replace it
+var readBuildInfo = debug.ReadBuildInfo
+
+// buildTimeLayouts are the formats accepted by ParseBuildTime, besides Unix seconds
+var buildTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02_15:04:05",
+	"2006-01-02",
+}
+
+// StalenessThreshold configures when builds of one stability level are considered old
+type StalenessThreshold struct {
+	// WarnAfter is the build age after which a warning is reported (zero disables the warning)
+	WarnAfter time.Duration
+	// ExpireAfter is the build age after which the build is expired (zero disables age based expiry)
+	ExpireAfter time.Duration
+	// ExpiresAt is a fixed date after which the build is expired (zero disables it)
+	ExpiresAt time.Time
+}
+
+// StalenessPolicy warns about, or refuses, old builds depending on their stability
+type StalenessPolicy struct {
+	// Thresholds maps a stability level to its limits, levels without entry never go stale
+	Thresholds map[Stability]StalenessThreshold
+	// Now returns the current time (defaults to time.Now), tests can replace it
+	Now func() time.Time
+}
+
+// StalenessResult is the outcome of StalenessPolicy.Check
+type StalenessResult struct {
+	// BuildTime is when the binary was built, zero if unknown
+	BuildTime time.Time
+	// Age is the time elapsed since BuildTime
+	Age time.Duration
+	// Stale is true when the build is older than WarnAfter
+	Stale bool
+	// Expired is true when the build is older than ExpireAfter or past ExpiresAt
+	Expired bool
+	// Warning is a message such as "this dev build is 45 days old; please update", empty when neither stale nor expired
+	Warning string
+}
+
+// DefaultStalenessPolicy warns about dev and canary builds older than 30 days and about alpha, beta and
+// rc builds older than 60 days, and expires alpha and beta builds after 180 days. Stable builds never go stale.
+func DefaultStalenessPolicy() *StalenessPolicy {
+	const day = 24 * time.Hour
+	return &StalenessPolicy{
+		Thresholds: map[Stability]StalenessThreshold{
+			StabilityCanary: {WarnAfter: 30 * day},
+			StabilityDev:    {WarnAfter: 30 * day},
+			StabilityAlpha:  {WarnAfter: 60 * day, ExpireAfter: 180 * day},
+			StabilityBeta:   {WarnAfter: 60 * day, ExpireAfter: 180 * day},
+			StabilityRC:     {WarnAfter: 60 * day},
+		},
+	}
+}
+
+// Check evaluates info against the threshold of its stability level
+// The build time comes from Info.BuildTime, falling back to the vcs.time recorded by the Go toolchain;
+// a build with an unknown build time is only checked against ExpiresAt.
+func (p *StalenessPolicy) Check(info *Info) *StalenessResult {
+	result := &StalenessResult{}
+	if p == nil || info == nil {
+		return result
+	}
+
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	current := now()
+
+	stability := info.Stability()
+	threshold, ok := p.Thresholds[stability]
+	if !ok {
+		return result
+	}
+
+	result.BuildTime = info.BuildTime
+	if result.BuildTime.IsZero() {
+		result.BuildTime, _ = BuildTimeFromBuildInfo()
+	}
+	if !result.BuildTime.IsZero() {
+		result.Age = current.Sub(result.BuildTime)
+		if result.Age < 0 {
+			result.Age = 0
+		}
+		result.Stale = threshold.WarnAfter > 0 && result.Age >= threshold.WarnAfter
+		result.Expired = threshold.ExpireAfter > 0 && result.Age >= threshold.ExpireAfter
+	}
+	pastExpiry := !threshold.ExpiresAt.IsZero() && !current.Before(threshold.ExpiresAt)
+	result.Expired = result.Expired || pastExpiry
+
+	label := "this build"
+	if stability != StabilityStable {
+		label = fmt.Sprintf("this %s build", stability)
+	}
+	switch {
+	case pastExpiry:
+		result.Warning = fmt.Sprintf("%s expired on %s; please update", label, threshold.ExpiresAt.Format("2006-01-02"))
+	case result.Expired:
+		result.Warning = fmt.Sprintf("%s is %s old and has expired; please update", label, formatDays(result.Age))
+	case result.Stale:
+		result.Warning = fmt.Sprintf("%s is %s old; please update", label, formatDays(result.Age))
+	}
+	return result
+}
+
+// Enforce returns an error wrapping ErrBuildExpired when info is expired under the policy
+func (p *StalenessPolicy) Enforce(info *Info) error {
+	result := p.Check(info)
+	if result.Expired {
+		return fmt.Errorf("%w: %s", ErrBuildExpired, result.Warning)
+	}
+	return nil
+}
+
+// Notices returns the warning as banner lines, for BannerOptions.Notices
+func (r *StalenessResult) Notices() []string {
+	if r == nil || r.Warning == "" {
+		return nil
+	}
+	return []string{r.Warning}
+}
+
+// ParseBuildTime parses a build time injected with ldflags, accepting RFC 3339, "2006-01-02 15:04:05"
+// style timestamps (UTC) and Unix seconds as produced by `date +%s`
+func ParseBuildTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("build time cannot be empty")
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range buildTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid build time: %s", value)
+}
+
+// BuildTimeFromBuildInfo returns the commit time recorded by the Go toolchain (vcs.time), if any
+func BuildTimeFromBuildInfo() (time.Time, bool) {
+	info, ok := readBuildInfo()
+	if !ok || info == nil {
+		return time.Time{}, false
+	}
+	for _, setting := range info.Settings {
+		if setting.Key != "vcs.time" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatDays renders a duration as a whole number of days, e.g. "45 days" or "1 day"
+func formatDays(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
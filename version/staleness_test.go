@@ -0,0 +1,113 @@
+package version
+
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+func TestStalenessPolicyCheck(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	policy := DefaultStalenessPolicy()
+	policy.Now = func() time.Time { return now }
+	policy.Thresholds[StabilityRC] = StalenessThreshold{ExpiresAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name        string
+		version     string
+		age         time.Duration
+		wantStale   bool
+		wantExpired bool
+		wantWarning string
+	}{
+		{name: "fresh dev build", version: "1.0.0-dev", age: 10 * day},
+		{
+			name:        "old dev build",
+			version:     "1.0.0-dev",
+			age:         45 * day,
+			wantStale:   true,
+			wantWarning: "this dev build is 45 days old; please update",
+		},
+		{
+			name:        "old beta build",
+			version:     "1.0.0-beta",
+			age:         200 * day,
+			wantStale:   true,
+			wantExpired: true,
+			wantWarning: "this beta build is 200 days old and has expired; please update",
+		},
+		{
+			name:        "release candidate past its expiry date",
+			version:     "1.0.0-rc2",
+			age:         day,
+			wantExpired: true,
+			wantWarning: "this rc build expired on 2025-05-01; please update",
+		},
+		{name: "stable builds never go stale", version: "1.0.0", age: 1000 * day},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := mustParse(t, tt.version)
+			info.BuildTime = now.Add(-tt.age)
+
+			result := policy.Check(info)
+			if result.Stale != tt.wantStale || result.Expired != tt.wantExpired {
+				t.Errorf("Check() Stale = %t, Expired = %t, want %t, %t", result.Stale, result.Expired, tt.wantStale, tt.wantExpired)
+			}
+			if result.Warning != tt.wantWarning {
+				t.Errorf("Check() Warning = %q, want %q", result.Warning, tt.wantWarning)
+			}
+
+			err := policy.Enforce(info)
+			if tt.wantExpired != errors.Is(err, ErrBuildExpired) {
+				t.Errorf("Enforce() error = %v, want expired = %t", err, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func TestStalenessBuildInfoFallback(t *testing.T) {
+	original := readBuildInfo
+	t.Cleanup(func() { readBuildInfo = original })
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Settings: []debug.BuildSetting{{Key: "vcs.time", Value: "2025-01-01T00:00:00Z"}}}, true
+	}
+
+	policy := &StalenessPolicy{
+		Thresholds: map[Stability]StalenessThreshold{StabilityCanary: {WarnAfter: time.Hour}},
+		Now:        func() time.Time { return time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC) },
+	}
+	result := policy.Check(mustParse(t, "1.0.0-canary"))
+	if !result.Stale || result.Warning != "this canary build is 1 day old; please update" {
+		t.Errorf("Check() = %+v, want a stale build based on vcs.time", result)
+	}
+	if notices := result.Notices(); len(notices) != 1 || notices[0] != result.Warning {
+		t.Errorf("Notices() = %q, want the warning", notices)
+	}
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	if result := policy.Check(mustParse(t, "1.0.0-canary")); result.Stale || result.Notices() != nil {
+		t.Errorf("Check() without build time = %+v, want no warning", result)
+	}
+}
+
+func TestParseBuildTime(t *testing.T) {
+	want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	for _, value := range []string{"2025-03-04T05:06:07Z", "2025-03-04T07:06:07+02:00", "2025-03-04 05:06:07", "2025-03-04_05:06:07", "1741064767"} {
+		got, err := ParseBuildTime(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseBuildTime(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "2025-13-01"} {
+		if _, err := ParseBuildTime(value); err == nil {
+			t.Errorf("ParseBuildTime(%q) expected error but got none", value)
+		}
+	}
+}
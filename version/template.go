@@ -0,0 +1,281 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Built-in banner templates, usable with BannerFromTemplate or by name with TemplateByName
+const (
+	// TemplateClassic is the *** box with the centered title and version followed by the metadata
+	TemplateClassic = `{{- define "body"}}
+{{range centerBlock .Width .Title}}{{.}}
+{{end}}
+{{center .Width .Version}}
+{{range .Notices}}{{center $.Width .}}
+{{end}}
+{{- if .MetadataLines}}
+{{range .MetadataLines}}{{.}}
+{{end}}{{end}}
+{{end -}}
+{{box 0 (include "body" .)}}`
+
+	// TemplateCompact is a single line, e.g. "MyApp v1.2.3 [BETA] | Author: Jane | Repo: github.com/acme/app"
+	TemplateCompact = `{{join " " (lines .AppName)}} {{.Version}}{{range .Notices}} - {{.}}{{end}}{{range .Metadata}} | {{.Key}}: {{.Value}}{{end}}`
+
+	// TemplateTwoColumn puts the title on the left and the version, notices and metadata on the right
+	TemplateTwoColumn = `{{- define "details"}}{{.Version}}
+{{range .Notices}}{{.}}
+{{end}}{{range .MetadataLines}}{{.}}
+{{end}}{{end -}}
+{{columns 4 .Title (include "details" .)}}`
+)
+
+// bannerTemplates maps the built-in template names accepted by TemplateByName
+var bannerTemplates = map[string]string{
+	"classic":    TemplateClassic,
+	"compact":    TemplateCompact,
+	"two-column": TemplateTwoColumn,
+}
+
+// TemplateData is the data a banner template is executed with
+type TemplateData struct {
+	// AppName is the application name as passed to BannerFromTemplate
+	AppName string
+	// Title is the rendered title: ASCII art lines when BannerOptions.UseASCII is set, the name lines otherwise
+	Title []string
+	// Info is the version information
+	Info *Info
+	// Version is the version line, e.g. "v1.2.3 ABC123 [BETA]"
+	Version string
+	// Notices are the non-empty BannerOptions.Notices
+	Notices []string
+	// Metadata lists the visible metadata fields in display order
+	Metadata []MetadataField
+	// MetadataLines are the metadata fields rendered as aligned "Label: value" lines
+	MetadataLines []string
+	// Width is the width of the widest line, or the inner width of a fixed-width box
+	Width int
+}
+
+// TemplateError is a template parse or execution error with its location in the template
+type TemplateError struct {
+	// Line and Column are 1-based, Column is 0 when unknown
+	Line   int
+	Column int
+	// Message describes the problem without the location
+	Message string
+	Err     error
+}
+
+// templateLocation matches the "template: name:line:col: " locations in text/template errors
+var templateLocation = regexp.MustCompile(`template: [^:]*:(\d+)(?::(\d+))?: `)
+
+// Error implements error
+func (e *TemplateError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("banner template: %s", e.Message)
+	}
+	if e.Column == 0 {
+		return fmt.Sprintf("banner template line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("banner template line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// Unwrap returns the underlying text/template error
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// newTemplateError extracts the location from a text/template error, errors raised inside include
+// carry several locations and the innermost one is where the problem is
+func newTemplateError(err error) *TemplateError {
+	msg := err.Error()
+	tErr := &TemplateError{Message: msg, Err: err}
+	if matches := templateLocation.FindAllStringSubmatchIndex(msg, -1); matches != nil {
+		m := matches[len(matches)-1]
+		tErr.Line, _ = strconv.Atoi(msg[m[2]:m[3]])
+		if m[4] >= 0 {
+			tErr.Column, _ = strconv.Atoi(msg[m[4]:m[5]])
+		}
+		tErr.Message = msg[m[1]:]
+	}
+	return tErr
+}
+
+// TemplateByName returns a built-in template by name: classic, compact or two-column
+func TemplateByName(name string) (string, error) {
+	tmpl, ok := bannerTemplates[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(bannerTemplates))
+		for n := range bannerTemplates {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("invalid banner template: %s (expected: %s)", name, strings.Join(names, ", "))
+	}
+	return tmpl, nil
+}
+
+// BannerFromTemplate renders a banner from a text/template layout with simple text titles
+// Besides the TemplateData fields, templates can use these helpers:
+//   - center width s, pad width s and padLeft width s align s in width columns
+//   - centerBlock width lines centers lines as a block, keeping ASCII art intact
+//   - repeat n s repeats s, width s returns the display width of s
+//   - lines s splits s into lines, join sep lines joins them
+//   - box width s draws a *** box around the lines of s (width 0 fits the content)
+//   - columns gap left right puts two blocks of lines side by side
+//   - include name data renders a {{define}} block to a string, to pass it to box or columns
+//
+// Parse and execution errors are returned as *TemplateError with the template line number.
+func BannerFromTemplate(tmpl, appName string, info *Info) (string, error) {
+	return BannerFromTemplateWithOptions(tmpl, appName, info, BannerOptions{FontStyle: FontStyleSlant})
+}
+
+// BannerFromTemplateWithOptions renders a banner from a template using custom options
+// UseASCII, FontStyle, FixedWidth, Notices and Metadata shape the template data; the layout is up to the template.
+func BannerFromTemplateWithOptions(tmpl, appName string, info *Info, opts BannerOptions) (string, error) {
+	t := template.New("banner")
+	t.Funcs(templateFuncs(t))
+	if _, err := t.Parse(tmpl); err != nil {
+		return "", newTemplateError(err)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, newTemplateData(appName, info, opts)); err != nil {
+		return "", newTemplateError(err)
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// newTemplateData builds the template data for appName and info
+func newTemplateData(appName string, info *Info, opts BannerOptions) TemplateData {
+	fixed := !opts.AutoWidth && opts.FixedWidth > 0
+	title := splitManualTitleLines(appName)
+	if opts.UseASCII {
+		available := 0
+		if fixed {
+			available = max(opts.FixedWidth-BorderAsterisk.overhead(defaultPadding)-paddingLeft*2, 1)
+		}
+		title = GenerateASCIIArtWithStyle(appName, available, opts.FontStyle)
+	}
+
+	fields := info.MetadataFields(opts.Metadata...)
+	data := TemplateData{
+		AppName:       appName,
+		Title:         title,
+		Info:          info,
+		Version:       formatVersionLine(info),
+		Notices:       nonEmptyLines(opts.Notices),
+		Metadata:      fields,
+		MetadataLines: lineTexts(metadataLines(fields, 0, opts.Overflow)),
+	}
+	data.Width = maxContentWidth(data.Title, info, data.Notices, data.MetadataLines)
+	if fixed {
+		data.Width = max(opts.FixedWidth-BorderAsterisk.overhead(defaultPadding), 0)
+	}
+	return data
+}
+
+// maxIncludeDepth limits nested include calls, each include runs a new execution that
+// text/template's own nesting limit does not see, so a recursive include would overflow the stack
+const maxIncludeDepth = 100
+
+// templateFuncs returns the helpers available to banner templates, include executes blocks of t
+func templateFuncs(t *template.Template) template.FuncMap {
+	depth := 0
+	return template.FuncMap{
+		"center": func(width int, s string) string { return centerText(s, width) },
+		"pad": func(width int, s string) string {
+			return s + strings.Repeat(" ", max(width-ansiWidth(s), 0))
+		},
+		"padLeft": func(width int, s string) string { return alignText(s, width, AlignRight) },
+		"centerBlock": func(width int, lines []string) []string {
+			return alignBlock(lines, width, AlignCenter)
+		},
+		"repeat":  func(n int, s string) string { return strings.Repeat(s, max(n, 0)) },
+		"width":   ansiWidth,
+		"lines":   templateLines,
+		"join":    func(sep string, lines []string) string { return strings.Join(lines, sep) },
+		"box":     templateBox,
+		"columns": templateColumns,
+		"include": func(name string, data any) (string, error) {
+			if depth >= maxIncludeDepth {
+				return "", fmt.Errorf("include %q: nested more than %d levels deep", name, maxIncludeDepth)
+			}
+			depth++
+			defer func() { depth-- }()
+
+			var b strings.Builder
+			if err := t.ExecuteTemplate(&b, name, data); err != nil {
+				return "", err
+			}
+			return b.String(), nil
+		},
+	}
+}
+
+// templateLines splits s into lines, ignoring a trailing newline
+func templateLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// templateBox draws a *** box of width columns around the lines of content, 0 fits the content
+func templateBox(width int, content string) string {
+	lines := templateLines(content)
+	if width <= 0 {
+		width = longestLineWidth(lines) + BorderAsterisk.overhead(defaultPadding)
+	}
+
+	boxed := []string{strings.Repeat(borderChar, width)}
+	for _, line := range lines {
+		boxed = append(boxed, formatBoxLineWithWidth(line, width))
+	}
+	boxed = append(boxed, strings.Repeat(borderChar, width))
+	return strings.Join(boxed, "\n")
+}
+
+// templateColumns puts left and right side by side, separated by gap spaces
+// Each side is either a []string or a string split into lines.
+func templateColumns(gap int, left, right any) (string, error) {
+	leftLines, err := columnLines(left)
+	if err != nil {
+		return "", err
+	}
+	rightLines, err := columnLines(right)
+	if err != nil {
+		return "", err
+	}
+
+	leftWidth := longestLineWidth(leftLines)
+	rows := max(len(leftLines), len(rightLines))
+	lines := make([]string, rows)
+	for idx := range lines {
+		var l, r string
+		if idx < len(leftLines) {
+			l = leftLines[idx]
+		}
+		if idx < len(rightLines) {
+			r = rightLines[idx]
+		}
+		line := l + strings.Repeat(" ", max(leftWidth-ansiWidth(l), 0)+max(gap, 0)) + r
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// columnLines converts a columns argument to lines
+func columnLines(v any) ([]string, error) {
+	switch value := v.(type) {
+	case []string:
+		return value, nil
+	case string:
+		return templateLines(value), nil
+	}
+	return nil, errors.New("columns expects strings or lists of lines")
+}
@@ -0,0 +1,100 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBannerFromTemplateBuiltins(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "beta", Author: "Jane", Repo: "github.com/acme/tool"}
+
+	t.Run("classic matches the default box", func(t *testing.T) {
+		got, err := BannerFromTemplate(TemplateClassic, "App", info)
+		if err != nil {
+			t.Fatalf("BannerFromTemplate() error = %v", err)
+		}
+		showBorder := true
+		if want := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, ShowBorder: &showBorder}); got != want {
+			t.Errorf("BannerFromTemplate() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		got, err := BannerFromTemplateWithOptions(TemplateCompact, "App", info, BannerOptions{Notices: []string{"Updated from v1.2.0"}})
+		if err != nil {
+			t.Fatalf("BannerFromTemplate() error = %v", err)
+		}
+		if want := "App v1.2.3 ABC123 [BETA] - Updated from v1.2.0 | Author: Jane | Repo: github.com/acme/tool"; got != want {
+			t.Errorf("BannerFromTemplate() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("two-column with ascii art", func(t *testing.T) {
+		got, err := BannerFromTemplateWithOptions(TemplateTwoColumn, "App", info, BannerOptions{UseASCII: true, FontStyle: FontStyleSmall})
+		if err != nil {
+			t.Fatalf("BannerFromTemplate() error = %v", err)
+		}
+		want := strings.Join([]string{
+			"    _                     v1.2.3 ABC123 [BETA]",
+			"   /_\\    _ __   _ __     Author:    Jane",
+			"  / _ \\  | '_ \\ | '_ \\    Repo:      github.com/acme/tool",
+			" /_/ \\_\\ | .__/ | .__/",
+			"         |_|    |_|",
+		}, "\n")
+		if got != want {
+			t.Errorf("BannerFromTemplate() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestBannerFromTemplateHelpers(t *testing.T) {
+	tmpl := `{{center 10 "ab"}}|{{pad 4 "ab"}}|{{padLeft 4 "ab"}}|{{repeat 3 "="}}|{{width "北京"}}
+{{box 8 "hi"}}`
+	got, err := BannerFromTemplate(tmpl, "App", &Info{Major: 1})
+	if err != nil {
+		t.Fatalf("BannerFromTemplate() error = %v", err)
+	}
+	want := "    ab    |ab  |  ab|===|4\n********\n* hi   *\n********"
+	if got != want {
+		t.Errorf("BannerFromTemplate() = %q, want %q", got, want)
+	}
+}
+
+func TestBannerFromTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		line int
+		want string
+	}{
+		{name: "parse error", tmpl: "{{.Version}}\n\n{{center 3 }", line: 3, want: "banner template line 3: unexpected"},
+		{name: "unknown function", tmpl: "{{.Version}}\n{{shout .Version}}", line: 2, want: `function "shout" not defined`},
+		{name: "unknown field", tmpl: "{{.Version}}\n  {{.Nope}}", line: 2, want: "banner template line 2, column 4: "},
+		{name: "inside include", tmpl: "{{define \"x\"}}\n\n{{.Nope}}{{end}}{{include \"x\" .}}", line: 3, want: "can't evaluate field Nope"},
+		{name: "recursive include", tmpl: `{{define "a"}}x{{include "a" .}}{{end}}{{include "a" .}}`, line: 1, want: `include "a": nested more than 100 levels deep`},
+		{name: "columns argument", tmpl: "{{columns 1 3 .Title}}", line: 1, want: "columns expects strings or lists of lines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BannerFromTemplate(tt.tmpl, "App", &Info{Major: 1})
+			var tErr *TemplateError
+			if !errors.As(err, &tErr) {
+				t.Fatalf("BannerFromTemplate() error = %v, want *TemplateError", err)
+			}
+			if tErr.Line != tt.line || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("BannerFromTemplate() error = %q (line %d), want line %d containing %q", err, tErr.Line, tt.line, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateByName(t *testing.T) {
+	if got, err := TemplateByName("Two-Column"); err != nil || got != TemplateTwoColumn {
+		t.Errorf("TemplateByName(\"Two-Column\") = %q, %v, want TemplateTwoColumn", got, err)
+	}
+	if _, err := TemplateByName("fancy"); err == nil || !strings.Contains(err.Error(), "classic, compact, two-column") {
+		t.Errorf("TemplateByName(\"fancy\") error = %v, want the list of templates", err)
+	}
+}
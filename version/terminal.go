@@ -0,0 +1,111 @@
+package version
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Terminal describes the output a banner is printed to
+type Terminal struct {
+	// IsTTY is true when the output is an interactive terminal (or CLICOLOR_FORCE is set)
+	IsTTY bool
+	// Width is the number of columns, 0 if unknown
+	Width int
+	// Profile is the color depth the output supports, ColorProfileNone for logs and NO_COLOR
+	Profile ColorProfile
+	// Dumb is true for TERM=dumb, which only supports plain text
+	Dumb bool
+}
+
+// TerminalDetector inspects the writer a banner is printed to
+type TerminalDetector func(w io.Writer) Terminal
+
+// DetectTerminal inspects w and the environment:
+//   - w is a TTY when it is a terminal file, CLICOLOR_FORCE (other than "0") forces TTY output
+//   - NO_COLOR (any value) and TERM=dumb disable colors, COLORTERM and TERM select the color depth
+//   - the width comes from the terminal (ioctl on Linux), falling back to $COLUMNS
+func DetectTerminal(w io.Writer) Terminal {
+	return detectTerminal(w, os.Getenv)
+}
+
+// detectTerminal implements DetectTerminal with an injectable environment
+func detectTerminal(w io.Writer, getenv func(string) string) Terminal {
+	var term Terminal
+
+	f, isFile := w.(*os.File)
+	if isFile && isTerminal(f) {
+		term.IsTTY = true
+		term.Width = terminalWidth(f)
+	}
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		term.IsTTY = true
+	}
+	if term.Width <= 0 {
+		if columns, err := strconv.Atoi(strings.TrimSpace(getenv("COLUMNS"))); err == nil && columns > 0 {
+			term.Width = columns
+		}
+	}
+
+	term.Dumb = strings.EqualFold(getenv("TERM"), "dumb")
+	switch {
+	case !term.IsTTY, term.Dumb, getenv("NO_COLOR") != "":
+		term.Profile = ColorProfileNone
+	default:
+		term.Profile = profileFromEnv(getenv)
+	}
+	return term
+}
+
+// BannerForTerminal adapts the banner to term: a one-line summary when the output is not a TTY (logs, CI),
+// simple text when the terminal is dumb or too narrow for the ASCII art, and the requested banner otherwise.
+// The banner width is capped to the terminal and colors from opts.Theme are downgraded to the terminal profile.
+func BannerForTerminal(appName string, info *Info, opts BannerOptions, term Terminal) string {
+	if !term.IsTTY {
+		return summaryLine(appName, info, opts.Notices)
+	}
+
+	if opts.Theme != nil {
+		theme := *opts.Theme
+		switch {
+		case term.Dumb || term.Profile == ColorProfileNone:
+			theme.Profile = ColorProfileNone
+		case term.Profile != ColorProfileAuto && (theme.Profile == ColorProfileAuto || theme.Profile > term.Profile):
+			theme.Profile = term.Profile
+		}
+		opts.Theme = &theme
+	}
+
+	if term.Dumb {
+		opts.UseASCII = false
+	}
+	if term.Width > 0 {
+		width := max(term.Width-max(opts.Margin.Left, 0), 1)
+
+		// The art needs the margin the layout keeps around it, and the border and padding of a box
+		allowance := artMargin
+		if showBorder, style, padding := bannerFrame(opts); showBorder {
+			allowance += style.overhead(padding)
+		}
+		if opts.UseASCII && longestLineWidth(GenerateASCIIArtWithStyle(appName, 0, opts.FontStyle))+allowance > width {
+			opts.UseASCII = false
+		}
+		if !opts.AutoWidth && opts.FixedWidth > width {
+			opts.FixedWidth = width
+		}
+		if opts.MaxWidth <= 0 || opts.MaxWidth > width {
+			opts.MaxWidth = width
+		}
+	}
+	return BannerWithOptions(appName, info, opts)
+}
+
+// summaryLine renders the banner as a single line, e.g. "MyApp v1.2.3 (ABC123) [BETA]"
+func summaryLine(appName string, info *Info, notices []string) string {
+	line := strings.TrimSpace(strings.Join(strings.Fields(appName), " ") + " " + info.Text())
+	for _, notice := range nonEmptyLines(notices) {
+		line += " - " + strings.TrimSpace(notice)
+	}
+	return line
+}
@@ -0,0 +1,34 @@
+//go:build linux
+
+package version
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the struct filled by the TIOCGWINSZ ioctl
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+// isTerminal reports whether f refers to a terminal
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TCGETS), uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
+
+// terminalWidth returns the number of columns of the terminal f refers to, 0 if unknown
+func terminalWidth(f *os.File) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.Col)
+}
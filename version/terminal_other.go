@@ -0,0 +1,16 @@
+//go:build !linux
+
+package version
+
+import "os"
+
+// isTerminal reports whether f is a character device, the closest portable approximation of a terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth is only implemented on Linux, other platforms rely on $COLUMNS
+func terminalWidth(f *os.File) int {
+	return 0
+}
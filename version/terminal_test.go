@@ -0,0 +1,177 @@
+package version
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectTerminal(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		name   string
+		writer io.Writer
+		env    map[string]string
+		want   Terminal
+	}{
+		{name: "buffer", writer: &bytes.Buffer{}, want: Terminal{Profile: ColorProfileNone}},
+		{name: "regular file", writer: file, env: map[string]string{"COLUMNS": "120"}, want: Terminal{Width: 120, Profile: ColorProfileNone}},
+		{
+			name:   "forced colors",
+			writer: &bytes.Buffer{},
+			env:    map[string]string{"CLICOLOR_FORCE": "1", "TERM": "xterm-256color"},
+			want:   Terminal{IsTTY: true, Profile: ColorProfileANSI256},
+		},
+		{name: "force disabled", writer: &bytes.Buffer{}, env: map[string]string{"CLICOLOR_FORCE": "0"}, want: Terminal{Profile: ColorProfileNone}},
+		{
+			name:   "NO_COLOR wins over CLICOLOR_FORCE",
+			writer: &bytes.Buffer{},
+			env:    map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1", "COLORTERM": "truecolor"},
+			want:   Terminal{IsTTY: true, Profile: ColorProfileNone},
+		},
+		{
+			name:   "dumb terminal",
+			writer: &bytes.Buffer{},
+			env:    map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb", "COLUMNS": "abc"},
+			want:   Terminal{IsTTY: true, Dumb: true, Profile: ColorProfileNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectTerminal(tt.writer, func(key string) string { return tt.env[key] })
+			if got != tt.want {
+				t.Errorf("detectTerminal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBannerForTerminal(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "beta", Author: "Jane Doe"}
+	asciiOpts := BannerOptions{UseASCII: true, AutoWidth: true, FontStyle: FontStyleStandard, Theme: DefaultTheme()}
+
+	t.Run("logs get a one-line summary", func(t *testing.T) {
+		got := BannerForTerminal("My\nApp", info, BannerOptions{UseASCII: true, Notices: []string{"Updated from v1.2.0"}}, Terminal{Width: 200})
+		if want := "My App v1.2.3 (ABC123) [BETA] - Updated from v1.2.0"; got != want {
+			t.Errorf("BannerForTerminal() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("wide terminal keeps ascii art and colors", func(t *testing.T) {
+		got := BannerForTerminal("App", info, asciiOpts, Terminal{IsTTY: true, Width: 200, Profile: ColorProfileANSI})
+		if got != BannerWithOptions("App", info, withProfile(asciiOpts, ColorProfileANSI)) {
+			t.Errorf("BannerForTerminal() should render the requested banner, got:\n%s", got)
+		}
+		if strings.Contains(got, "38;2;") || strings.Contains(got, "38;5;") {
+			t.Errorf("colors should be downgraded to 16 colors, got %q", got)
+		}
+	})
+
+	t.Run("narrow terminal falls back to simple text", func(t *testing.T) {
+		got := BannerForTerminal("Application", info, asciiOpts, Terminal{IsTTY: true, Width: 30, Profile: ColorProfileNone})
+		if !strings.HasPrefix(got, "Application\n") || strings.Contains(got, "\x1b") {
+			t.Errorf("BannerForTerminal() = %q, want uncolored simple text", got)
+		}
+	})
+
+	t.Run("dumb terminal", func(t *testing.T) {
+		got := BannerForTerminal("App", info, asciiOpts, Terminal{IsTTY: true, Dumb: true})
+		if !strings.HasPrefix(got, "App\n") || strings.Contains(got, "\x1b") {
+			t.Errorf("BannerForTerminal() = %q, want uncolored simple text", got)
+		}
+	})
+
+	t.Run("fixed width is clamped", func(t *testing.T) {
+		got := BannerForTerminal("App", info, BannerOptions{FixedWidth: 80}, Terminal{IsTTY: true, Width: 40})
+		if first := strings.Split(got, "\n")[0]; len(first) != 40 {
+			t.Errorf("border width = %d, want 40", len(first))
+		}
+	})
+
+	t.Run("fixed width leaves room for the margin", func(t *testing.T) {
+		got := BannerForTerminal("App", info, BannerOptions{FixedWidth: 80, Margin: BannerMargin{Left: 4}}, Terminal{IsTTY: true, Width: 40})
+		if first := strings.Split(got, "\n")[0]; len(first) != 40 {
+			t.Errorf("indented border width = %d, want 40", len(first))
+		}
+	})
+
+	t.Run("border and padding leave no room for the art", func(t *testing.T) {
+		rounded, padding, showBorder := BorderRounded, 3, true
+		opts := BannerOptions{AutoWidth: true, UseASCII: true, FontStyle: FontStyleStandard, ShowBorder: &showBorder, Border: &rounded, Padding: &padding}
+		width := longestLineWidth(GenerateASCIIArtWithStyle("App", 0, FontStyleStandard)) + 6
+
+		got := BannerForTerminal("App", info, opts, Terminal{IsTTY: true, Width: width})
+		if !strings.Contains(got, " App ") || strings.Contains(got, "_") {
+			t.Errorf("BannerForTerminal() =\n%s\nwant the simple text title instead of clipped art", got)
+		}
+	})
+
+	t.Run("lines fit the terminal", func(t *testing.T) {
+		rounded, padding, showBorder := BorderRounded, 3, true
+		info := &Info{Major: 1, Minor: 2, Patch: 3, Suffix: "beta", Repo: "https://github.com/acme/application-with-a-long-name"}
+		tests := []struct {
+			name string
+			opts BannerOptions
+		}{
+			{name: "auto width", opts: BannerOptions{AutoWidth: true}},
+			{name: "auto width ascii", opts: BannerOptions{AutoWidth: true, UseASCII: true, FontStyle: FontStyleStandard}},
+			{name: "wide border ascii", opts: BannerOptions{AutoWidth: true, UseASCII: true, FontStyle: FontStyleStandard, ShowBorder: &showBorder, Border: &rounded, Padding: &padding}},
+			{name: "fixed width ascii", opts: BannerOptions{FixedWidth: 120, UseASCII: true, FontStyle: FontStyleStandard, Padding: &padding}},
+		}
+		for _, tt := range tests {
+			for width := 40; width <= 90; width++ {
+				got := BannerForTerminal("Application", info, tt.opts, Terminal{IsTTY: true, Width: width})
+				for _, line := range strings.Split(got, "\n") {
+					if stringWidth(line) > width {
+						t.Fatalf("%s: line %q is %d columns wide, want at most %d", tt.name, line, stringWidth(line), width)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestPrintWithOptionsTerminalAware(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	var detected io.Writer
+	PrintWithOptions("App", &Info{Major: 1}, BannerOptions{
+		TerminalAware: true,
+		Detector: func(w io.Writer) Terminal {
+			detected = w
+			return Terminal{}
+		},
+	})
+	_ = writer.Close()
+	out, _ := io.ReadAll(reader)
+
+	if detected != writer {
+		t.Errorf("detector received %v, want stdout", detected)
+	}
+	if string(out) != "App v1.0.0\n" {
+		t.Errorf("printed %q, want the one-line summary", out)
+	}
+}
+
+// withProfile returns opts with a copy of its theme using profile
+func withProfile(opts BannerOptions, profile ColorProfile) BannerOptions {
+	theme := *opts.Theme
+	theme.Profile = profile
+	opts.Theme = &theme
+	return opts
+}
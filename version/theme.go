@@ -0,0 +1,394 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const ansiReset = "\x1b[0m"
+
+// ColorProfile is the color depth supported by the output
+type ColorProfile int
+
+const (
+	// ColorProfileAuto - pick the depth from the COLORTERM and TERM environment variables
+	ColorProfileAuto ColorProfile = iota
+	// ColorProfileNone - no escape sequences at all
+	ColorProfileNone
+	// ColorProfileANSI - the 16 standard colors
+	ColorProfileANSI
+	// ColorProfileANSI256 - the xterm 256-color palette
+	ColorProfileANSI256
+	// ColorProfileTrueColor - 24-bit colors
+	ColorProfileTrueColor
+)
+
+// Color is a terminal color: a name such as "red" or "bright-yellow", a 256-color palette index such as "208",
+// or a hex value such as "#ff8800". Colors are downgraded to the closest match the profile supports;
+// an empty color leaves the text untouched.
+type Color string
+
+// Theme colors the parts of a banner
+type Theme struct {
+	// Border colors the border box
+	Border Color
+	// Title colors the application name or its ASCII art
+	Title Color
+	// Version colors the version number and hash
+	Version Color
+	// Suffix colors the suffix badge per stability level, e.g. red [DEV] and yellow [BETA]
+	Suffix map[Stability]Color
+	// Label colors the metadata labels ("Author:", "Repo:", ...)
+	Label Color
+	// Value colors the metadata values
+	Value Color
+	// Notice colors the notice lines
+	Notice Color
+	// Profile is the color depth of the output (defaults to ColorProfileAuto)
+	Profile ColorProfile
+}
+
+// ansiNames maps the color names to their index in the 16-color palette
+var ansiNames = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+	"bright-black": 8, "gray": 8, "grey": 8, "bright-red": 9, "bright-green": 10, "bright-yellow": 11,
+	"bright-blue": 12, "bright-magenta": 13, "bright-cyan": 14, "bright-white": 15,
+}
+
+// ansiPalette holds the RGB values of the 16 standard colors (xterm defaults)
+var ansiPalette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 cube of the 256-color palette
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// DefaultTheme returns a theme with a cyan border, a bright title and colored suffix badges
+func DefaultTheme() *Theme {
+	return &Theme{
+		Border:  "cyan",
+		Title:   "bright-white",
+		Version: "bright-green",
+		Suffix: map[Stability]Color{
+			StabilityCanary: "magenta",
+			StabilityDev:    "red",
+			StabilityAlpha:  "#ff8700",
+			StabilityBeta:   "yellow",
+			StabilityRC:     "cyan",
+		},
+		Label:  "bright-black",
+		Value:  "white",
+		Notice: "yellow",
+	}
+}
+
+// Validate returns an error for the first color that cannot be parsed, checking the fields in
+// declaration order and then the suffix colors sorted by stability
+func (t *Theme) Validate() error {
+	parts := []string{"border", "title", "version", "label", "value", "notice"}
+	colors := []Color{t.Border, t.Title, t.Version, t.Label, t.Value, t.Notice}
+
+	stabilities := make([]string, 0, len(t.Suffix))
+	for stability := range t.Suffix {
+		stabilities = append(stabilities, string(stability))
+	}
+	sort.Strings(stabilities)
+	for _, stability := range stabilities {
+		parts = append(parts, "suffix "+stability)
+		colors = append(colors, t.Suffix[Stability(stability)])
+	}
+
+	for idx, color := range colors {
+		if _, ok := color.sequence(ColorProfileTrueColor); color != "" && !ok {
+			return fmt.Errorf("invalid %s color: %q", parts[idx], color)
+		}
+	}
+	return nil
+}
+
+// paint wraps text in the escape sequences of color for the theme profile
+func (t *Theme) paint(color Color, text string) string {
+	if t == nil || color == "" || text == "" {
+		return text
+	}
+	sequence, ok := color.sequence(t.profile())
+	if !ok || sequence == "" {
+		return text
+	}
+	return sequence + text + ansiReset
+}
+
+// suffixColor returns the badge color for a suffix
+func (t *Theme) suffixColor(suffix string) Color {
+	if t == nil {
+		return ""
+	}
+	return t.Suffix[stabilityOf(suffix)]
+}
+
+// profile resolves ColorProfileAuto from the environment
+func (t *Theme) profile() ColorProfile {
+	if t.Profile != ColorProfileAuto {
+		return t.Profile
+	}
+	return profileFromEnv(os.Getenv)
+}
+
+// profileFromEnv picks a color depth from COLORTERM and TERM
+func profileFromEnv(getenv func(string) string) ColorProfile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorProfileTrueColor
+	}
+	if strings.Contains(strings.ToLower(getenv("TERM")), "256color") {
+		return ColorProfileANSI256
+	}
+	return ColorProfileANSI
+}
+
+// sequence returns the SGR foreground sequence for the color downgraded to profile,
+// reporting false when the color cannot be parsed
+func (c Color) sequence(profile ColorProfile) (string, bool) {
+	name := strings.ToLower(strings.TrimSpace(string(c)))
+	if name == "" {
+		return "", true
+	}
+
+	var r, g, b, index int
+	isIndex := false
+	switch {
+	case strings.HasPrefix(name, "#"):
+		var ok bool
+		if r, g, b, ok = parseHexColor(name); !ok {
+			return "", false
+		}
+	default:
+		if n, ok := ansiNames[name]; ok {
+			index, isIndex = n, true
+			break
+		}
+		n, err := strconv.Atoi(name)
+		if err != nil || n < 0 || n > 255 {
+			return "", false
+		}
+		index, isIndex = n, true
+	}
+
+	if isIndex {
+		switch {
+		case profile == ColorProfileNone:
+			return "", true
+		case index < 16:
+			return ansi16Sequence(index), true
+		case profile == ColorProfileANSI:
+			r, g, b = paletteRGB(index)
+			return ansi16Sequence(nearestANSI(r, g, b)), true
+		default:
+			return fmt.Sprintf("\x1b[38;5;%dm", index), true
+		}
+	}
+
+	switch profile {
+	case ColorProfileNone:
+		return "", true
+	case ColorProfileANSI:
+		return ansi16Sequence(nearestANSI(r, g, b)), true
+	case ColorProfileANSI256:
+		return fmt.Sprintf("\x1b[38;5;%dm", nearest256(r, g, b)), true
+	default:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b), true
+	}
+}
+
+// rgb returns the RGB value of the color, palette colors using the xterm defaults
+func (c Color) rgb() (int, int, int, bool) {
+	name := strings.ToLower(strings.TrimSpace(string(c)))
+	if strings.HasPrefix(name, "#") {
+		return parseHexColor(name)
+	}
+	index, ok := ansiNames[name]
+	if !ok {
+		n, err := strconv.Atoi(name)
+		if err != nil || n < 0 || n > 255 {
+			return 0, 0, 0, false
+		}
+		index = n
+	}
+	r, g, b := paletteRGB(index)
+	return r, g, b, true
+}
+
+// hex returns the color as #rrggbb, or "" when it is empty or invalid
+func (c Color) hex() string {
+	r, g, b, ok := c.rgb()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// parseHexColor parses #rgb or #rrggbb
+func parseHexColor(value string) (int, int, int, bool) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff), true
+}
+
+// ansi16Sequence returns the SGR sequence of a 16-color palette index
+func ansi16Sequence(index int) string {
+	if index < 8 {
+		return fmt.Sprintf("\x1b[%dm", 30+index)
+	}
+	return fmt.Sprintf("\x1b[%dm", 90+index-8)
+}
+
+// paletteRGB returns the RGB value of a 256-color palette index
+func paletteRGB(index int) (int, int, int) {
+	switch {
+	case index < 16:
+		c := ansiPalette[index]
+		return c[0], c[1], c[2]
+	case index < 232:
+		index -= 16
+		return cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6]
+	default:
+		level := 8 + (index-232)*10
+		return level, level, level
+	}
+}
+
+// nearestANSI returns the 16-color palette index closest to the RGB value
+func nearestANSI(r, g, b int) int {
+	best, bestDistance := 0, -1
+	for index, c := range ansiPalette {
+		if d := colorDistance(r, g, b, c[0], c[1], c[2]); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = index, d
+		}
+	}
+	return best
+}
+
+// nearest256 returns the 256-color palette index closest to the RGB value, from the cube or the grey ramp
+func nearest256(r, g, b int) int {
+	cube := 16 + 36*nearestCubeLevel(r) + 6*nearestCubeLevel(g) + nearestCubeLevel(b)
+	cr, cg, cb := paletteRGB(cube)
+
+	grey := (r + g + b) / 3
+	greyIndex := 232
+	if grey > 8 {
+		greyIndex = 232 + min((grey-8+5)/10, 23)
+	}
+	gr, gg, gb := paletteRGB(greyIndex)
+
+	if colorDistance(r, g, b, gr, gg, gb) < colorDistance(r, g, b, cr, cg, cb) {
+		return greyIndex
+	}
+	return cube
+}
+
+// nearestCubeLevel returns the index of the cube level closest to a channel value
+func nearestCubeLevel(v int) int {
+	best := 0
+	for idx, level := range cubeLevels {
+		if abs(v-level) < abs(v-cubeLevels[best]) {
+			best = idx
+		}
+	}
+	return best
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// stripANSI removes the CSI escape sequences (colors, styles) from s
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+	for idx := 0; idx < len(s); {
+		if n := ansiSequenceLen(s[idx:]); n > 0 {
+			idx += n
+			continue
+		}
+		b.WriteByte(s[idx])
+		idx++
+	}
+	return b.String()
+}
+
+// ansiSequenceLen returns the length of the escape sequence at the start of s, 0 if there is none
+func ansiSequenceLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	if s[1] != '[' {
+		return 2
+	}
+	for idx := 2; idx < len(s); idx++ {
+		if s[idx] >= 0x40 && s[idx] <= 0x7e {
+			return idx + 1
+		}
+	}
+	return len(s)
+}
+
+// ansiWidth returns the number of terminal columns s takes, ignoring escape sequences
+func ansiWidth(s string) int {
+	return stringWidth(stripANSI(s))
+}
+
+// truncateANSI cuts s to width columns without splitting grapheme clusters, keeping escape sequences
+// and resetting colors left open
+func truncateANSI(s string, width int) string {
+	if ansiWidth(s) <= width {
+		return s
+	}
+
+	var b strings.Builder
+	visible := 0
+	colored := false
+	for idx := 0; idx < len(s); {
+		if n := ansiSequenceLen(s[idx:]); n > 0 {
+			sequence := s[idx : idx+n]
+			b.WriteString(sequence)
+			colored = sequence != ansiReset
+			idx += n
+			continue
+		}
+		size, clusterWidth := nextGrapheme(s[idx:])
+		if visible+clusterWidth > width {
+			break
+		}
+		b.WriteString(s[idx : idx+size])
+		visible += clusterWidth
+		idx += size
+	}
+	if colored {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
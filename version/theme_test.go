@@ -0,0 +1,163 @@
+package version
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestColorSequence(t *testing.T) {
+	tests := []struct {
+		color   Color
+		profile ColorProfile
+		want    string
+	}{
+		{color: "red", profile: ColorProfileTrueColor, want: "\x1b[31m"},
+		{color: "Bright-Yellow", profile: ColorProfileANSI, want: "\x1b[93m"},
+		{color: "red", profile: ColorProfileNone, want: ""},
+		{color: "208", profile: ColorProfileANSI256, want: "\x1b[38;5;208m"},
+		{color: "208", profile: ColorProfileTrueColor, want: "\x1b[38;5;208m"},
+		{color: "196", profile: ColorProfileANSI, want: "\x1b[91m"},
+		{color: "#ff8800", profile: ColorProfileTrueColor, want: "\x1b[38;2;255;136;0m"},
+		{color: "#f80", profile: ColorProfileTrueColor, want: "\x1b[38;2;255;136;0m"},
+		{color: "#ff8700", profile: ColorProfileANSI256, want: "\x1b[38;5;208m"},
+		{color: "#808080", profile: ColorProfileANSI256, want: "\x1b[38;5;244m"},
+		{color: "#0000ff", profile: ColorProfileANSI, want: "\x1b[34m"},
+		{color: "#ff0000", profile: ColorProfileANSI, want: "\x1b[91m"},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.color.sequence(tt.profile)
+		if !ok || got != tt.want {
+			t.Errorf("Color(%q).sequence(%d) = %q, %t, want %q", tt.color, tt.profile, got, ok, tt.want)
+		}
+	}
+
+	for _, invalid := range []Color{"purple-ish", "#12345", "#gggggg", "256", "-1"} {
+		if _, ok := invalid.sequence(ColorProfileTrueColor); ok {
+			t.Errorf("Color(%q).sequence() ok = true, want false", invalid)
+		}
+	}
+}
+
+func TestThemeValidate(t *testing.T) {
+	if err := DefaultTheme().Validate(); err != nil {
+		t.Errorf("DefaultTheme().Validate() unexpected error: %v", err)
+	}
+
+	theme := DefaultTheme()
+	theme.Suffix[StabilityBeta] = "not-a-color"
+	if err := theme.Validate(); err == nil || !strings.Contains(err.Error(), "suffix beta") {
+		t.Errorf("Validate() error = %v, want invalid suffix beta color", err)
+	}
+
+	// With several invalid colors, the error always names the same one
+	theme = DefaultTheme()
+	theme.Notice = "nope"
+	theme.Label = "also-nope"
+	theme.Suffix[StabilityRC] = "bad"
+	theme.Suffix[StabilityAlpha] = "bad"
+	for range 20 {
+		if err := theme.Validate(); err == nil || err.Error() != `invalid label color: "also-nope"` {
+			t.Fatalf("Validate() error = %v, want invalid label color", err)
+		}
+	}
+	theme.Label, theme.Notice = "", ""
+	for range 20 {
+		if err := theme.Validate(); err == nil || err.Error() != `invalid suffix alpha color: "bad"` {
+			t.Fatalf("Validate() error = %v, want invalid suffix alpha color", err)
+		}
+	}
+}
+
+func TestProfileFromEnv(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want ColorProfile
+	}{
+		{env: map[string]string{"COLORTERM": "truecolor", "TERM": "xterm"}, want: ColorProfileTrueColor},
+		{env: map[string]string{"COLORTERM": "24bit"}, want: ColorProfileTrueColor},
+		{env: map[string]string{"TERM": "xterm-256color"}, want: ColorProfileANSI256},
+		{env: map[string]string{"TERM": "xterm"}, want: ColorProfileANSI},
+	}
+	for _, tt := range tests {
+		if got := profileFromEnv(func(key string) string { return tt.env[key] }); got != tt.want {
+			t.Errorf("profileFromEnv(%v) = %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestANSIHelpers(t *testing.T) {
+	colored := "\x1b[31mhello\x1b[0m \x1b[38;2;1;2;3mwörld\x1b[0m"
+
+	if got := stripANSI(colored); got != "hello wörld" {
+		t.Errorf("stripANSI() = %q, want %q", got, "hello wörld")
+	}
+	if got := ansiWidth(colored); got != 11 {
+		t.Errorf("ansiWidth() = %d, want 11", got)
+	}
+	if got := visibleWidth(colored + "\x1b[32m   \x1b[0m"); got != 11 {
+		t.Errorf("visibleWidth() = %d, want 11 ignoring trailing colored spaces", got)
+	}
+	if got := centerText("\x1b[31mab\x1b[0m", 6); got != "  \x1b[31mab\x1b[0m  " {
+		t.Errorf("centerText() = %q, want colored text padded by two spaces", got)
+	}
+
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{width: 3, want: "\x1b[31mhel\x1b[0m"},
+		{width: 8, want: "\x1b[31mhello\x1b[0m \x1b[38;2;1;2;3mwö\x1b[0m"},
+		{width: 20, want: colored},
+	}
+	for _, tt := range tests {
+		if got := truncateANSI(colored, tt.width); got != tt.want {
+			t.Errorf("truncateANSI(%d) = %q, want %q", tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestBannerWithThemeStaysAligned(t *testing.T) {
+	info := &Info{Major: 1, Minor: 2, Patch: 3, Hash: "ABC123", Suffix: "dev", Author: "Jane Doe", Repo: "github.com/acme/tool"}
+	theme := DefaultTheme()
+	theme.Profile = ColorProfileTrueColor
+	showBorder := true
+
+	tests := []struct {
+		name string
+		opts BannerOptions
+	}{
+		{name: "auto width", opts: BannerOptions{AutoWidth: true, ShowBorder: &showBorder, Theme: theme, Notices: []string{"Updated from v1.2.0"}}},
+		{name: "fixed width", opts: BannerOptions{FixedWidth: 50, Theme: theme}},
+		{name: "ascii art", opts: BannerOptions{UseASCII: true, FixedWidth: 80, FontStyle: FontStyleStandard, Theme: theme}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plainOpts := tt.opts
+			plainOpts.Theme = nil
+			plain := BannerWithOptions("Tool", info, plainOpts)
+			colored := BannerWithOptions("Tool", info, tt.opts)
+
+			if !strings.Contains(colored, "\x1b[31m[DEV]\x1b[0m") {
+				t.Errorf("colored banner should contain a red [DEV] badge:\n%q", colored)
+			}
+			if stripANSI(colored) != plain {
+				t.Errorf("colored banner without escape sequences differs from the plain banner:\n%s\n---\n%s", stripANSI(colored), plain)
+			}
+
+			lines := strings.Split(stripANSI(colored), "\n")
+			for _, line := range lines[1:] {
+				if utf8.RuneCountInString(line) != utf8.RuneCountInString(lines[0]) {
+					t.Errorf("line %q is not aligned with the border %q", line, lines[0])
+				}
+			}
+		})
+	}
+
+	theme.Profile = ColorProfileNone
+	if colored := BannerWithOptions("Tool", info, BannerOptions{AutoWidth: true, Theme: theme}); strings.Contains(colored, "\x1b") {
+		t.Errorf("ColorProfileNone should not emit escape sequences: %q", colored)
+	}
+}
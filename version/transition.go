@@ -0,0 +1,289 @@
+package version
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultStateFileName = "last-run.json"
+	stateLockTimeout     = 3 * time.Second
+	// stateLockStaleAfter is far above the time needed to rewrite the small state file, so that a slow holder
+	// keeps its lock; a lock left by a crashed process on this host is broken sooner, once its owner is gone
+	stateLockStaleAfter = 30 * time.Second
+	stateLockRetryDelay = 10 * time.Millisecond
+)
+
+// TransitionKind describes how the running version relates to the previous run
+type TransitionKind string
+
+const (
+	// TransitionFirstRun - no previous run was recorded (or the state file was unreadable)
+	TransitionFirstRun TransitionKind = "first_run"
+	// TransitionUpgraded - the previous run used an older version
+	TransitionUpgraded TransitionKind = "upgraded"
+	// TransitionDowngraded - the previous run used a newer version
+	TransitionDowngraded TransitionKind = "downgraded"
+	// TransitionSame - the previous run used the same version
+	TransitionSame TransitionKind = "same"
+)
+
+// Transition is the outcome of CheckTransition
+type Transition struct {
+	Kind TransitionKind
+	// From is the version of the previous run, nil on first run
+	From *Info
+	// To is the running version
+	To *Info
+	// LastRun is when the previous run was recorded, zero on first run
+	LastRun time.Time
+}
+
+// transitionState is the content of the state file
+type transitionState struct {
+	Version string    `json:"version"`
+	LastRun time.Time `json:"last_run"`
+}
+
+// stateMutex serializes CheckTransition calls within the process, the lock file covers other processes
+var stateMutex sync.Mutex
+
+// DefaultStatePath returns the state file used for appName: $XDG_STATE_HOME/<appName>/last-run.json,
+// falling back to ~/.local/state/<appName>/last-run.json
+func DefaultStatePath(appName string) (string, error) {
+	if appName == "" {
+		return "", errors.New("application name cannot be empty")
+	}
+
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" || !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot locate the state directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, appName, defaultStateFileName), nil
+}
+
+// CheckTransition compares current with the version recorded in stateFile and records current as the last run.
+// An empty stateFile uses DefaultStatePath for the program name. A missing or corrupt state file reports
+// TransitionFirstRun and is replaced; the file is written atomically and guarded by a lock file so that
+// concurrent starts never observe a partial state.
+func CheckTransition(stateFile string, current *Info) (*Transition, error) {
+	if current == nil {
+		return nil, errors.New("current version cannot be nil")
+	}
+	if stateFile == "" {
+		path, err := DefaultStatePath(filepath.Base(os.Args[0]))
+		if err != nil {
+			return nil, err
+		}
+		stateFile = path
+	}
+
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0o750); err != nil {
+		return nil, fmt.Errorf("cannot create state directory: %w", err)
+	}
+	unlock, err := lockStateFile(stateFile + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	transition := &Transition{Kind: TransitionFirstRun, To: current}
+	if state, ok := readTransitionState(stateFile); ok {
+		transition.From, transition.LastRun = state.from, state.LastRun
+		switch c := Compare(current, state.from); {
+		case c > 0:
+			transition.Kind = TransitionUpgraded
+		case c < 0:
+			transition.Kind = TransitionDowngraded
+		default:
+			transition.Kind = TransitionSame
+		}
+	}
+
+	raw, err := json.Marshal(transitionState{
+		Version: formatVersionString(current.Major, current.Minor, current.Patch, current.Hash, current.Suffix),
+		LastRun: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(stateFile, raw, 0o600); err != nil {
+		return nil, fmt.Errorf("cannot write state file: %w", err)
+	}
+
+	return transition, nil
+}
+
+// Message returns a notice for the banner such as "Updated from v1.2.0", or "" on first run and same version
+func (t *Transition) Message() string {
+	if t == nil || t.From == nil {
+		return ""
+	}
+	switch t.Kind {
+	case TransitionUpgraded:
+		return fmt.Sprintf("Updated from %s", t.From.Short())
+	case TransitionDowngraded:
+		return fmt.Sprintf("Downgraded from %s, data written by the newer version may not be readable", t.From.Short())
+	default:
+		return ""
+	}
+}
+
+// parsedTransitionState is a state file whose version parsed successfully
+type parsedTransitionState struct {
+	transitionState
+	from *Info
+}
+
+// readTransitionState reads the state file, reporting false when it is missing or corrupt
+func readTransitionState(path string) (*parsedTransitionState, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var state transitionState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, false
+	}
+	from, err := Parse(state.Version)
+	if err != nil {
+		return nil, false
+	}
+	return &parsedTransitionState{transitionState: state, from: from}, true
+}
+
+// lockStateFile creates path exclusively, waiting for other holders and breaking locks left by crashed processes
+// The lock holds a token naming its owner, and is only removed on unlock while it still holds that token.
+func lockStateFile(path string) (func(), error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock state file: %w", err)
+	}
+
+	deadline := time.Now().Add(stateLockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("cannot lock state file: %w", err)
+			}
+			return func() { unlockStateFile(path, token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("cannot lock state file: %w", err)
+		}
+
+		if breakStaleLock(path) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("cannot lock state file: %s is held by another process", path)
+		}
+		time.Sleep(stateLockRetryDelay)
+	}
+}
+
+// newLockToken returns "<host> <pid> <random>", identifying a lock holder and the host its pid belongs to
+func newLockToken() (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %d %s", lockHost(), os.Getpid(), hex.EncodeToString(random)), nil
+}
+
+// lockHost returns the host name written in lock tokens, "-" when it is unknown
+func lockHost() string {
+	if host, err := os.Hostname(); err == nil && host != "" && !strings.ContainsAny(host, " \t\n") {
+		return host
+	}
+	return "-"
+}
+
+// unlockStateFile removes the lock at path unless it has been broken and taken by another holder since
+func unlockStateFile(path, token string) {
+	if content, err := os.ReadFile(path); err == nil && string(content) == token {
+		_ = os.Remove(path)
+	}
+}
+
+// breakStaleLock removes the lock at path when it is stale, reporting whether it did
+// The lock is moved aside before it is checked again, so that when another waiter has just broken the stale
+// lock and created its own, that fresh lock is put back instead of being deleted.
+func breakStaleLock(path string) bool {
+	if !lockIsStale(path) {
+		return false
+	}
+
+	aside := fmt.Sprintf("%s.%d.stale", path, os.Getpid())
+	if err := os.Rename(path, aside); err != nil {
+		return false
+	}
+	defer func() { _ = os.Remove(aside) }()
+
+	if !lockIsStale(aside) {
+		// Link fails rather than replacing a lock created in the meantime
+		_ = os.Link(aside, path)
+		return false
+	}
+	return true
+}
+
+// lockIsStale reports whether the lock at path is older than stateLockStaleAfter, or was taken by a process
+// of this host that is no longer running
+func lockIsStale(path string) bool {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if time.Since(stat.ModTime()) > stateLockStaleAfter {
+		return true
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) != 3 {
+		// Still being written, or written by an older version: only its age tells
+		return false
+	}
+	pid, err := strconv.Atoi(fields[1])
+	if err != nil || fields[0] == "-" || fields[0] != lockHost() {
+		return false
+	}
+	return !processRunning(pid)
+}
+
+// processRunning reports whether pid is a running process, assuming it is when that cannot be told
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return true
+	}
+	return !errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
@@ -0,0 +1,244 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckTransition(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "tool", "last-run.json")
+
+	steps := []struct {
+		current     string
+		wantKind    TransitionKind
+		wantFrom    string
+		wantMessage string
+	}{
+		{current: "1.2.0", wantKind: TransitionFirstRun},
+		{current: "1.2.0:ABC123", wantKind: TransitionSame, wantFrom: "1.2.0"},
+		{current: "1.3.0-beta", wantKind: TransitionUpgraded, wantFrom: "1.2.0:ABC123", wantMessage: "Updated from v1.2.0"},
+		{current: "1.3.0", wantKind: TransitionUpgraded, wantFrom: "1.3.0-beta", wantMessage: "Updated from v1.3.0-beta"},
+		{
+			current:     "1.2.5",
+			wantKind:    TransitionDowngraded,
+			wantFrom:    "1.3.0",
+			wantMessage: "Downgraded from v1.3.0, data written by the newer version may not be readable",
+		},
+	}
+
+	for _, step := range steps {
+		transition, err := CheckTransition(stateFile, mustParse(t, step.current))
+		if err != nil {
+			t.Fatalf("CheckTransition(%s) unexpected error: %v", step.current, err)
+		}
+		if transition.Kind != step.wantKind {
+			t.Errorf("CheckTransition(%s) Kind = %q, want %q", step.current, transition.Kind, step.wantKind)
+		}
+		gotFrom := ""
+		if transition.From != nil {
+			gotFrom = transition.From.String()
+		}
+		if !strings.EqualFold(gotFrom, step.wantFrom) {
+			t.Errorf("CheckTransition(%s) From = %q, want %q", step.current, gotFrom, step.wantFrom)
+		}
+		if got := transition.Message(); got != step.wantMessage {
+			t.Errorf("CheckTransition(%s) Message() = %q, want %q", step.current, got, step.wantMessage)
+		}
+	}
+
+	if _, err := os.Stat(stateFile + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after CheckTransition")
+	}
+}
+
+func TestCheckTransitionStaleLock(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "last-run.json")
+	lockFile := stateFile + ".lock"
+	if err := os.WriteFile(lockFile, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	crashed := time.Now().Add(-2 * stateLockStaleAfter)
+	if err := os.Chtimes(lockFile, crashed, crashed); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	start := time.Now()
+	if _, err := CheckTransition(stateFile, mustParse(t, "1.0.0")); err != nil {
+		t.Fatalf("CheckTransition() with a stale lock error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= stateLockTimeout {
+		t.Errorf("CheckTransition() took %v, want the stale lock broken without waiting for the timeout", elapsed)
+	}
+	if _, err := os.Stat(lockFile); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after breaking a stale lock")
+	}
+}
+
+func TestCheckTransitionDeadOwnerLock(t *testing.T) {
+	// A process that has exited leaves its pid unused
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stateFile := filepath.Join(t.TempDir(), "last-run.json")
+	token := fmt.Sprintf("%s %d 0123456789abcdef", lockHost(), cmd.ProcessState.Pid())
+	if err := os.WriteFile(stateFile+".lock", []byte(token), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	start := time.Now()
+	if _, err := CheckTransition(stateFile, mustParse(t, "1.0.0")); err != nil {
+		t.Fatalf("CheckTransition() with a lock of an exited process error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= stateLockTimeout {
+		t.Errorf("CheckTransition() took %v, want the lock of an exited process broken without waiting", elapsed)
+	}
+}
+
+func TestBreakStaleLockKeepsFreshLock(t *testing.T) {
+	for _, content := range []string{"", fmt.Sprintf("%s %d 0123456789abcdef", lockHost(), os.Getpid())} {
+		lockFile := filepath.Join(t.TempDir(), "last-run.json.lock")
+		if err := os.WriteFile(lockFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		if breakStaleLock(lockFile) {
+			t.Errorf("breakStaleLock() = true for a fresh lock %q, want false", content)
+		}
+		if _, err := os.Stat(lockFile); err != nil {
+			t.Errorf("fresh lock %q removed: %v", content, err)
+		}
+		entries, _ := os.ReadDir(filepath.Dir(lockFile))
+		if len(entries) != 1 {
+			t.Errorf("directory has %d entries, want the lock file only", len(entries))
+		}
+	}
+}
+
+func TestUnlockKeepsTakenOverLock(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "last-run.json.lock")
+	unlock, err := lockStateFile(lockFile)
+	if err != nil {
+		t.Fatalf("lockStateFile() error = %v", err)
+	}
+
+	// The lock was broken and taken by another process in the meantime
+	if err := os.WriteFile(lockFile, []byte("other-host 1 fedcba9876543210"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(lockFile); err != nil {
+		t.Errorf("unlock removed a lock held by another process: %v", err)
+	}
+
+	if err := os.Remove(lockFile); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	unlock, err = lockStateFile(lockFile)
+	if err != nil {
+		t.Fatalf("lockStateFile() error = %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(lockFile); !os.IsNotExist(err) {
+		t.Errorf("unlock left its own lock behind")
+	}
+}
+
+func TestCheckTransitionCorruptState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "last-run.json")
+
+	for _, content := range []string{"{corrupt", `{"version": "not-a-version"}`} {
+		if err := os.WriteFile(stateFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		transition, err := CheckTransition(stateFile, mustParse(t, "2.0.0"))
+		if err != nil {
+			t.Fatalf("CheckTransition() unexpected error: %v", err)
+		}
+		if transition.Kind != TransitionFirstRun || transition.From != nil {
+			t.Errorf("CheckTransition() with state %q = %+v, want first run", content, transition)
+		}
+	}
+
+	// The corrupt file has been replaced
+	transition, err := CheckTransition(stateFile, mustParse(t, "2.0.0"))
+	if err != nil || transition.Kind != TransitionSame {
+		t.Errorf("CheckTransition() after repair = %+v, %v, want same", transition, err)
+	}
+}
+
+func TestCheckTransitionConcurrent(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "last-run.json")
+	if _, err := CheckTransition(stateFile, mustParse(t, "1.0.0")); err != nil {
+		t.Fatalf("CheckTransition() unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := CheckTransition(stateFile, mustParse(t, "1.1.0")); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent CheckTransition() error: %v", err)
+	}
+
+	state, ok := readTransitionState(stateFile)
+	if !ok || state.Version != "1.1.0" {
+		t.Errorf("state after concurrent runs = %+v, want 1.1.0", state)
+	}
+}
+
+func TestDefaultStatePath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/var/state")
+	if got, _ := DefaultStatePath("tool"); got != filepath.Join("/var/state", "tool", "last-run.json") {
+		t.Errorf("DefaultStatePath() = %q, want XDG_STATE_HOME based path", got)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/user")
+	if got, _ := DefaultStatePath("tool"); got != filepath.Join("/home/user", ".local", "state", "tool", "last-run.json") {
+		t.Errorf("DefaultStatePath() = %q, want ~/.local/state based path", got)
+	}
+
+	if _, err := DefaultStatePath(""); err == nil {
+		t.Errorf("DefaultStatePath(\"\") expected error but got none")
+	}
+}
+
+func TestBannerNotices(t *testing.T) {
+	info := mustParse(t, "1.3.0")
+	transition := &Transition{Kind: TransitionUpgraded, From: mustParse(t, "1.2.0"), To: info}
+
+	banner := BannerWithOptions("App", info, BannerOptions{AutoWidth: true, Notices: []string{transition.Message(), ""}})
+	lines := strings.Split(banner, "\n")
+	if len(lines) < 4 || !strings.Contains(lines[2], "v1.3.0") || strings.TrimSpace(lines[3]) != "Updated from v1.2.0" {
+		t.Errorf("banner notice should follow the version line, got:\n%s", banner)
+	}
+
+	showBorder := true
+	boxed := BannerWithOptions("App", info, BannerOptions{
+		AutoWidth:  true,
+		ShowBorder: &showBorder,
+		Notices:    []string{"A much longer notice than the title"},
+	})
+	for _, line := range strings.Split(boxed, "\n") {
+		if len(line) != len("* A much longer notice than the title *") {
+			t.Errorf("box line %q does not fit the notice width", line)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultGitHubAPI       = "https://api.github.com"
+	defaultUpdateCacheTTL  = 24 * time.Hour
+	defaultUpdateTimeout   = 10 * time.Second
+	maxReleaseFeedBodySize = 10 << 20
+)
+
+// Release describes a published version in a release feed
+type Release struct {
+	Version     string    `json:"version"`
+	URL         string    `json:"url,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets,omitempty"`
+}
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int64  `json:"size,omitempty"`
+	// OS and Arch are the GOOS/GOARCH the asset is built for, used by SelfUpdater
+	OS   string `json:"os,omitempty"`
+	Arch string `json:"arch,omitempty"`
+	// SHA256 is the hex encoded SHA-256 checksum of the asset
+	SHA256 string `json:"sha256,omitempty"`
+	// Signature is the base64 encoded ed25519 signature of the asset SignedAssetMessage
+	Signature string `json:"signature,omitempty"`
+}
+
+// Info parses the release version
+func (r *Release) Info() (*Info, error) {
+	return Parse(r.Version)
+}
+
+// ReleaseSource lists the releases published for an application
+type ReleaseSource interface {
+	Releases(ctx context.Context) ([]Release, error)
+}
+
+// ReleaseFeed is the JSON document read by FileReleaseSource
+// Example:
+//
+//	{"releases": [{"version": "1.5.0", "url": "https://example.com/1.5.0", "published_at": "2025-05-01T00:00:00Z"}]}
+type ReleaseFeed struct {
+	Releases []Release `json:"releases"`
+}
+
+// FileReleaseSource reads releases from a static ReleaseFeed JSON file
+type FileReleaseSource struct {
+	Path string
+}
+
+// Releases implements ReleaseSource
+func (s *FileReleaseSource) Releases(ctx context.Context) ([]Release, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read release feed: %w", err)
+	}
+
+	var feed ReleaseFeed
+	if err := json.Unmarshal(raw, &feed); err != nil {
+		return nil, fmt.Errorf("invalid release feed %s: %w", s.Path, err)
+	}
+	return feed.Releases, nil
+}
+
+// GitHubReleaseSource lists the releases of a GitHub repository through the REST API
+// Draft releases are skipped and tags such as "v1.5.0-beta" are used as versions.
+type GitHubReleaseSource struct {
+	Owner string
+	Repo  string
+	// BaseURL is the API endpoint (defaults to https://api.github.com)
+	BaseURL string
+	// Token is an optional API token, raising the rate limit
+	Token string
+	// Client is the HTTP client to use (defaults to http.DefaultClient)
+	Client *http.Client
+}
+
+// githubRelease is the subset of the GitHub release payload used by GitHubReleaseSource
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	Body        string    `json:"body"`
+	Draft       bool      `json:"draft"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+		Size               int64  `json:"size"`
+	} `json:"assets"`
+}
+
+// NewGitHubReleaseSource returns a source for github.com/owner/repo
+func NewGitHubReleaseSource(owner, repo string) *GitHubReleaseSource {
+	return &GitHubReleaseSource{Owner: owner, Repo: repo}
+}
+
+// Releases implements ReleaseSource
+func (s *GitHubReleaseSource) Releases(ctx context.Context) ([]Release, error) {
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGitHubAPI
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=50",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(s.Owner), url.PathEscape(s.Repo))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	var payload []githubRelease
+	if err := getJSON(s.Client, req, &payload); err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	for _, gr := range payload {
+		if gr.Draft {
+			continue
+		}
+		release := Release{
+			Version:     gr.TagName,
+			URL:         gr.HTMLURL,
+			Notes:       gr.Body,
+			PublishedAt: gr.PublishedAt,
+		}
+		for _, asset := range gr.Assets {
+			release.Assets = append(release.Assets, Asset{Name: asset.Name, URL: asset.BrowserDownloadURL, Size: asset.Size})
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+// getJSON performs req and decodes a successful JSON response into v
+func getJSON(client *http.Client, req *http.Request, v any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status fetching %s: %s", req.URL.Redacted(), resp.Status)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxReleaseFeedBodySize)).Decode(v); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", req.URL.Redacted(), err)
+	}
+	return nil
+}
+
+// UpdateChecker compares the releases of a ReleaseSource with the running version
+type UpdateChecker struct {
+	// Source lists the published releases
+	Source ReleaseSource
+	// Current is the running version
+	Current *Info
+	// Channel is the least stable release accepted (defaults to the stability of Current,
+	// so stable builds only hear about stable releases and beta builds also about betas and rcs)
+	Channel Stability
+	// CacheFile stores the last result so the source is queried at most once per CacheTTL (empty disables caching,
+	// a cache that cannot be written is ignored)
+	CacheFile string
+	// CacheTTL is how long a cached result stays valid (defaults to 24 hours)
+	CacheTTL time.Duration
+	// Timeout bounds a check against the source (defaults to 10 seconds)
+	Timeout time.Duration
+	// Now returns the current time (defaults to time.Now), tests can replace it
+	Now func() time.Time
+}
+
+// UpdateResult is the outcome of UpdateChecker.Check
+type UpdateResult struct {
+	// Current is the running version
+	Current *Info
+	// Latest is the newest release on the channel, nil if the source has none
+	Latest *Release
+	// Available is true when Latest is newer than Current
+	Available bool
+	// CheckedAt is when the source was queried
+	CheckedAt time.Time
+	// Cached is true when the result was read from the cache file
+	Cached bool
+}
+
+// updateCache is the content of the cache file
+type updateCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   Stability `json:"channel"`
+	Latest    *Release  `json:"latest,omitempty"`
+}
+
+// NewUpdateChecker returns a checker for the current version using default settings
+func NewUpdateChecker(source ReleaseSource, current *Info) *UpdateChecker {
+	return &UpdateChecker{Source: source, Current: current}
+}
+
+// Check returns the newest release on the channel and whether it is newer than the running version
+func (c *UpdateChecker) Check(ctx context.Context) (*UpdateResult, error) {
+	if c.Source == nil || c.Current == nil {
+		return nil, errors.New("update checker needs a source and the current version")
+	}
+
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	channel := c.Channel
+	if channel == "" {
+		channel = c.Current.Stability()
+	}
+	ttl := c.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultUpdateCacheTTL
+	}
+
+	if cached, ok := c.readCache(channel, now(), ttl); ok {
+		return c.result(cached.Latest, cached.CheckedAt, true), nil
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultUpdateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	releases, err := c.Source.Releases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check for updates: %w", err)
+	}
+
+	latest, _ := latestRelease(releases, channel)
+	checkedAt := now()
+	// The cache is best-effort, a read-only home directory must not turn every check into a failure
+	_ = c.writeCache(updateCache{CheckedAt: checkedAt, Channel: channel, Latest: latest})
+
+	return c.result(latest, checkedAt, false), nil
+}
+
+// result builds an UpdateResult comparing latest with the running version
+func (c *UpdateChecker) result(latest *Release, checkedAt time.Time, cached bool) *UpdateResult {
+	r := &UpdateResult{Current: c.Current, Latest: latest, CheckedAt: checkedAt, Cached: cached}
+	if latest != nil {
+		if info, err := latest.Info(); err == nil {
+			r.Available = Compare(info, c.Current) > 0
+		}
+	}
+	return r
+}
+
+// readCache returns the cached result when it is still fresh and was stored for the same channel
+func (c *UpdateChecker) readCache(channel Stability, now time.Time, ttl time.Duration) (*updateCache, bool) {
+	if c.CacheFile == "" {
+		return nil, false
+	}
+
+	raw, err := os.ReadFile(c.CacheFile)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached updateCache
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return nil, false
+	}
+	if cached.Channel != channel || now.Before(cached.CheckedAt) || now.Sub(cached.CheckedAt) >= ttl {
+		return nil, false
+	}
+	return &cached, true
+}
+
+// writeCache stores the result in the cache file, if caching is enabled
+func (c *UpdateChecker) writeCache(cached updateCache) error {
+	if c.CacheFile == "" {
+		return nil
+	}
+
+	raw, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.CacheFile, raw, 0o600); err != nil {
+		return fmt.Errorf("cannot write update cache: %w", err)
+	}
+	return nil
+}
+
+// Message returns a notice such as "a newer version 1.5.0 is available", or "" when up to date
+func (r *UpdateResult) Message() string {
+	if r == nil || !r.Available {
+		return ""
+	}
+	return fmt.Sprintf("a newer version %s is available", strings.TrimPrefix(strings.TrimPrefix(r.Latest.Version, "v"), "V"))
+}
+
+// latestRelease returns the release with the highest precedence whose stability is at least channel
+// Releases with unparsable versions are ignored.
+func latestRelease(releases []Release, channel Stability) (*Release, *Info) {
+	var latest *Release
+	var latestInfo *Info
+	for idx := range releases {
+		info, err := releases[idx].Info()
+		if err != nil || !info.Stability().AtLeast(channel) {
+			continue
+		}
+		if latestInfo == nil || Compare(info, latestInfo) > 0 {
+			latest = &releases[idx]
+			latestInfo = info
+		}
+	}
+	return latest, latestInfo
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it over path,
+// so readers never observe a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,264 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const githubReleasesPayload = `[
+	{"tag_name": "v1.6.0-beta", "html_url": "https://github.com/acme/tool/releases/v1.6.0-beta", "draft": false, "published_at": "2025-05-10T00:00:00Z"},
+	{"tag_name": "v2.0.0", "html_url": "https://github.com/acme/tool/releases/v2.0.0", "draft": true},
+	{"tag_name": "v1.5.0", "html_url": "https://github.com/acme/tool/releases/v1.5.0", "draft": false, "published_at": "2025-05-01T00:00:00Z",
+	 "assets": [{"name": "tool_linux_amd64", "browser_download_url": "https://github.com/acme/tool/releases/download/v1.5.0/tool_linux_amd64", "size": 42}]},
+	{"tag_name": "nightly", "html_url": "https://github.com/acme/tool/releases/nightly", "draft": false},
+	{"tag_name": "v1.4.0", "html_url": "https://github.com/acme/tool/releases/v1.4.0", "draft": false}
+]`
+
+// newGitHubServer serves githubReleasesPayload and counts the requests it receives
+func newGitHubServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.URL.Path != "/repos/acme/tool/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(githubReleasesPayload))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubReleaseSource(t *testing.T) {
+	var hits int32
+	server := newGitHubServer(t, &hits)
+
+	source := &GitHubReleaseSource{Owner: "acme", Repo: "tool", BaseURL: server.URL, Token: "secret"}
+	releases, err := source.Releases(context.Background())
+	if err != nil {
+		t.Fatalf("Releases() unexpected error: %v", err)
+	}
+
+	if len(releases) != 4 {
+		t.Fatalf("got %d releases, want 4 (drafts skipped): %+v", len(releases), releases)
+	}
+	if releases[1].Version != "v1.5.0" || len(releases[1].Assets) != 1 || releases[1].Assets[0].Size != 42 {
+		t.Errorf("release = %+v, want v1.5.0 with one asset", releases[1])
+	}
+
+	source.Token = ""
+	if _, err := source.Releases(context.Background()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Releases() without token error = %v, want 401 status", err)
+	}
+}
+
+func TestUpdateCheckerChannels(t *testing.T) {
+	var hits int32
+	server := newGitHubServer(t, &hits)
+	source := &GitHubReleaseSource{Owner: "acme", Repo: "tool", BaseURL: server.URL, Token: "secret"}
+
+	tests := []struct {
+		name          string
+		current       string
+		channel       Stability
+		wantLatest    string
+		wantAvailable bool
+		wantMessage   string
+	}{
+		{
+			name:          "stable build sees stable releases",
+			current:       "1.4.0",
+			wantLatest:    "v1.5.0",
+			wantAvailable: true,
+			wantMessage:   "a newer version 1.5.0 is available",
+		},
+		{
+			name:          "beta build sees beta releases",
+			current:       "1.5.0-beta",
+			wantLatest:    "v1.6.0-beta",
+			wantAvailable: true,
+			wantMessage:   "a newer version 1.6.0-beta is available",
+		},
+		{
+			name:          "explicit channel overrides current stability",
+			current:       "1.5.0",
+			channel:       StabilityBeta,
+			wantLatest:    "v1.6.0-beta",
+			wantAvailable: true,
+		},
+		{
+			name:       "up to date",
+			current:    "1.5.0:ABC123",
+			wantLatest: "v1.5.0",
+		},
+		{
+			name:       "ahead of feed",
+			current:    "1.7.0-dev",
+			wantLatest: "v1.6.0-beta",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewUpdateChecker(source, mustParse(t, tt.current))
+			checker.Channel = tt.channel
+
+			result, err := checker.Check(context.Background())
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+			if result.Latest == nil || result.Latest.Version != tt.wantLatest {
+				t.Fatalf("Latest = %+v, want %s", result.Latest, tt.wantLatest)
+			}
+			if result.Available != tt.wantAvailable {
+				t.Errorf("Available = %t, want %t", result.Available, tt.wantAvailable)
+			}
+			if tt.wantMessage != "" && result.Message() != tt.wantMessage {
+				t.Errorf("Message() = %q, want %q", result.Message(), tt.wantMessage)
+			}
+			if !tt.wantAvailable && result.Message() != "" {
+				t.Errorf("Message() = %q, want empty", result.Message())
+			}
+		})
+	}
+}
+
+func TestUpdateCheckerCache(t *testing.T) {
+	var hits int32
+	server := newGitHubServer(t, &hits)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cacheFile := filepath.Join(t.TempDir(), "cache", "update.json")
+	checker := &UpdateChecker{
+		Source:    &GitHubReleaseSource{Owner: "acme", Repo: "tool", BaseURL: server.URL, Token: "secret"},
+		Current:   mustParse(t, "1.4.0"),
+		CacheFile: cacheFile,
+		CacheTTL:  time.Hour,
+		Now:       func() time.Time { return now },
+	}
+
+	first, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if first.Cached || hits != 1 {
+		t.Fatalf("first check Cached = %t, hits = %d, want fresh result and 1 hit", first.Cached, hits)
+	}
+
+	now = now.Add(30 * time.Minute)
+	second, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if !second.Cached || hits != 1 || !second.Available || second.Latest.Version != "v1.5.0" {
+		t.Errorf("second check = %+v, hits = %d, want cached v1.5.0 and 1 hit", second, hits)
+	}
+
+	// The cache keeps the release, so the comparison follows the running version
+	checker.Current = mustParse(t, "1.5.0")
+	third, _ := checker.Check(context.Background())
+	if !third.Cached || third.Available {
+		t.Errorf("third check = %+v, want cached result without update", third)
+	}
+
+	// A different channel ignores the cache
+	checker.Channel = StabilityBeta
+	if _, err := checker.Check(context.Background()); err != nil || hits != 2 {
+		t.Errorf("channel change check error = %v, hits = %d, want 2 hits", err, hits)
+	}
+	checker.Channel = ""
+
+	now = now.Add(2 * time.Hour)
+	fourth, _ := checker.Check(context.Background())
+	if fourth.Cached || hits != 3 {
+		t.Errorf("expired check Cached = %t, hits = %d, want fresh result and 3 hits", fourth.Cached, hits)
+	}
+
+	if err := os.WriteFile(cacheFile, []byte("{corrupt"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if fifth, err := checker.Check(context.Background()); err != nil || fifth.Cached || hits != 4 {
+		t.Errorf("corrupt cache check error = %v, hits = %d, want fresh result and 4 hits", err, hits)
+	}
+}
+
+func TestUpdateCheckerUnwritableCache(t *testing.T) {
+	var hits int32
+	server := newGitHubServer(t, &hits)
+
+	// The cache directory is a regular file, so the cache cannot be written even as root
+	parent := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(parent, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	checker := &UpdateChecker{
+		Source:    &GitHubReleaseSource{Owner: "acme", Repo: "tool", BaseURL: server.URL, Token: "secret"},
+		Current:   mustParse(t, "1.4.0"),
+		CacheFile: filepath.Join(parent, "update.json"),
+	}
+
+	for i := 1; i <= 2; i++ {
+		result, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("Check() unexpected error: %v", err)
+		}
+		if result.Cached || !result.Available || result.Latest.Version != "v1.5.0" || hits != int32(i) {
+			t.Errorf("check %d = %+v, hits = %d, want fresh v1.5.0 and %d hits", i, result, hits, i)
+		}
+	}
+}
+
+func TestUpdateCheckerTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	checker := &UpdateChecker{
+		Source:  &GitHubReleaseSource{Owner: "acme", Repo: "tool", BaseURL: server.URL},
+		Current: mustParse(t, "1.0.0"),
+		Timeout: 50 * time.Millisecond,
+	}
+
+	start := time.Now()
+	if _, err := checker.Check(context.Background()); err == nil {
+		t.Fatalf("Check() expected timeout error but got none")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Check() took %s, want it bounded by the timeout", elapsed)
+	}
+}
+
+func TestFileReleaseSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "releases.json")
+	feed := `{"releases": [{"version": "0.9.0"}, {"version": "1.0.0-rc1"}, {"version": "1.0.0-rc2"}]}`
+	if err := os.WriteFile(path, []byte(feed), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	result, err := NewUpdateChecker(&FileReleaseSource{Path: path}, mustParse(t, "1.0.0-rc1")).Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if !result.Available || result.Latest.Version != "1.0.0-rc2" {
+		t.Errorf("result = %+v, want 1.0.0-rc2 available", result)
+	}
+
+	if _, err := (&FileReleaseSource{Path: filepath.Join(t.TempDir(), "missing.json")}).Releases(context.Background()); err == nil {
+		t.Errorf("Releases() with missing file expected error but got none")
+	}
+}
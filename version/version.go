@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Info holds version information
@@ -21,13 +22,19 @@ type Info struct {
 	Company   string
 	Copyright string
 	Repo      string
+	Branch    string          // Git branch the build was made from
+	BuildTime time.Time       // When the binary was built, zero if unknown
+	Dirty     bool            // Built from a working tree with uncommitted changes
+	Metadata  []MetadataField // Extra ordered fields such as License or Docs, shown after Repo
 }
 
 // VersionJSON represents version information in JSON format
 type VersionJSON struct {
-	Version   string  `json:"version"`
-	Hash      *string `json:"hash,omitempty"`
-	BuildType *string `json:"build_type,omitempty"`
+	Version   string          `json:"version"`
+	Hash      *string         `json:"hash,omitempty"`
+	BuildType *string         `json:"build_type,omitempty"`
+	Features  []string        `json:"features,omitempty"`
+	Metadata  []MetadataField `json:"metadata,omitempty"`
 }
 
 var versionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?::([A-Fa-f0-9]+))?(?:-(alpha|beta|dev|rc\d+|canary))?$`)
@@ -83,6 +90,18 @@ func Parse(versionStr string) (*Info, error) {
 	return info, nil
 }
 
+// formatVersionString builds a version string in the format accepted by Parse (X.Y.Z[:HASH][-suffix])
+func formatVersionString(major, minor, patch int, hash, suffix string) string {
+	ver := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+	if hash != "" {
+		ver += ":" + hash
+	}
+	if suffix != "" {
+		ver += "-" + suffix
+	}
+	return ver
+}
+
 // String returns the full version string
 func (i *Info) String() string {
 	return i.Version
@@ -138,17 +157,7 @@ func (i *Info) Text() string {
 
 // JSON returns the version information as a JSON string
 func (i *Info) JSON() (string, error) {
-	vj := VersionJSON{
-		Version: fmt.Sprintf("%d.%d.%d", i.Major, i.Minor, i.Patch),
-	}
-
-	if i.Hash != "" {
-		vj.Hash = &i.Hash
-	}
-
-	if i.Suffix != "" {
-		vj.BuildType = &i.Suffix
-	}
+	vj := i.ToJSON()
 
 	bytes, err := json.Marshal(vj)
 	if err != nil {
@@ -160,17 +169,7 @@ func (i *Info) JSON() (string, error) {
 
 // JSONPretty returns the version information as a pretty-printed JSON string
 func (i *Info) JSONPretty() (string, error) {
-	vj := VersionJSON{
-		Version: fmt.Sprintf("%d.%d.%d", i.Major, i.Minor, i.Patch),
-	}
-
-	if i.Hash != "" {
-		vj.Hash = &i.Hash
-	}
-
-	if i.Suffix != "" {
-		vj.BuildType = &i.Suffix
-	}
+	vj := i.ToJSON()
 
 	bytes, err := json.MarshalIndent(vj, "", "  ")
 	if err != nil {
@@ -194,5 +193,7 @@ func (i *Info) ToJSON() VersionJSON {
 		vj.BuildType = &i.Suffix
 	}
 
+	vj.Metadata = visibleMetadata(i.Metadata)
+
 	return vj
 }
@@ -0,0 +1,136 @@
+package version
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges lists the East Asian Wide (W) and Fullwidth (F) code points, including emoji with
+// default emoji presentation, which terminals render two columns wide (Unicode 15, ranges merged)
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4}, {0x17000, 0x18CFF}, {0x1AFF0, 0x1B2FF},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F1E6, 0x1F1FF},
+	{0x1F200, 0x1F202}, {0x1F210, 0x1F23B}, {0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265},
+	{0x1F300, 0x1F320}, {0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440},
+	{0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567}, {0x1F57A, 0x1F57A},
+	{0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC},
+	{0x1F6D0, 0x1F6D2}, {0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF},
+	{0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	emojiPresentation = '\ufe0f'
+)
+
+// runeWidth returns the number of columns r takes on its own: 0 for control characters, combining
+// marks and other invisible code points, 2 for wide and fullwidth characters, 1 otherwise
+func runeWidth(r rune) int {
+	switch {
+	case r == 0, r < 0x20, r >= 0x7F && r < 0xA0:
+		return 0
+	case r < 0x300:
+		return 1 // fast path for ASCII and Latin-1, which have no combining marks
+	case isGraphemeExtend(r), unicode.Is(unicode.Cf, r):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether r is listed in wideRanges
+func isWide(r rune) bool {
+	idx := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	return idx < len(wideRanges) && wideRanges[idx][0] <= r
+}
+
+// isGraphemeExtend reports whether r attaches to the previous character without taking a column:
+// combining marks, variation selectors, emoji skin tone modifiers, Hangul vowel/final jamo and emoji tags
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner:
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // skin tone modifiers
+		return true
+	case r >= 0x1160 && r <= 0x11FF: // Hangul medial vowels and final consonants
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tags used by subdivision flags
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// isPictographic approximates Extended_Pictographic, the emoji that ZWJ sequences join
+func isPictographic(r rune) bool {
+	return (r >= 0x2600 && r <= 0x27BF) || (r >= 0x1F000 && r <= 0x1FAFF) || r == 0x2B1B || r == 0x2B1C || r == 0x2B50 || r == 0x2B55
+}
+
+// isRegionalIndicator reports whether r is one of the letters pairing up into flag emoji
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// nextGrapheme returns the byte length and display width of the grapheme cluster at the start of s.
+// It implements the parts of UAX #29 that matter for terminal output: combining sequences, CRLF,
+// emoji modifier and ZWJ sequences, flags, and VS16 turning a text character into a wide emoji.
+func nextGrapheme(s string) (size, width int) {
+	if s == "" {
+		return 0, 0
+	}
+	base, size := utf8.DecodeRuneInString(s)
+	width = runeWidth(base)
+	if base == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2, 0
+	}
+
+	prev := base
+	regionalIndicators := 0
+	if isRegionalIndicator(base) {
+		regionalIndicators = 1
+	}
+	for size < len(s) {
+		r, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case prev == zeroWidthJoiner && isPictographic(r):
+			// the joined emoji is drawn as a single glyph
+		case isRegionalIndicator(r) && regionalIndicators == 1:
+			regionalIndicators++
+		case r == emojiPresentation:
+			if width == 1 {
+				width = 2
+			}
+		case unicode.Is(unicode.Mc, r):
+			width++ // spacing marks stay with their base but take a column, as in most terminals
+		case isGraphemeExtend(r):
+		default:
+			return size, width
+		}
+		prev = r
+		size += n
+	}
+	return size, width
+}
+
+// stringWidth returns the number of terminal columns s takes, s must not contain escape sequences
+func stringWidth(s string) int {
+	width := 0
+	for idx := 0; idx < len(s); {
+		n, w := nextGrapheme(s[idx:])
+		width += w
+		idx += n
+	}
+	return width
+}
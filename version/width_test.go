@@ -0,0 +1,95 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{name: "ascii", s: "Acme Corp", want: 9},
+		{name: "latin precomposed", s: "Café Münster", want: 12},
+		{name: "latin decomposed", s: "Cafe\u0301 Mu\u0308nster", want: 12},
+		{name: "chinese", s: "北京科技有限公司", want: 16},
+		{name: "japanese", s: "株式会社カタカナ", want: 16},
+		{name: "halfwidth katakana", s: "ｶﾀｶﾅ", want: 4},
+		{name: "korean precomposed", s: "한국어", want: 6},
+		{name: "korean jamo", s: "\u1112\u1161\u11ab\u1100\u116e\u11a8", want: 4},
+		{name: "fullwidth latin", s: "ＡＢＣ", want: 6},
+		{name: "mixed", s: "Go 语言", want: 7},
+		{name: "emoji", s: "🚀 Launch", want: 9},
+		{name: "emoji with skin tone", s: "👍🏽", want: 2},
+		{name: "zwj family", s: "👨\u200d👩\u200d👧\u200d👦", want: 2},
+		{name: "flag", s: "🇵🇹🇯🇵", want: 4},
+		{name: "text symbol with emoji presentation", s: "❤\ufe0f", want: 2},
+		{name: "text symbol", s: "❤", want: 1},
+		{name: "keycap", s: "1\ufe0f\u20e3", want: 2},
+		{name: "devanagari", s: "नमस्ते", want: 4},
+		{name: "devanagari spacing marks", s: "हिंदी", want: 4},
+		{name: "thai", s: "สวัสดี", want: 4},
+		{name: "arabic", s: "مرحبا", want: 5},
+		{name: "hebrew with points", s: "שָׁלוֹם", want: 4},
+		{name: "box drawing", s: "╔═╗", want: 3},
+		{name: "zero width space", s: "a\u200bb", want: 2},
+		{name: "control characters", s: "a\tb\x00", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringWidth(tt.s); got != tt.want {
+				t.Errorf("stringWidth(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateANSIKeepsGraphemes(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{s: "Cafe\u0301 Bar", width: 4, want: "Cafe\u0301"},
+		{s: "北京科技", width: 5, want: "北京"},
+		{s: "👨\u200d👩\u200d👧 family", width: 3, want: "👨\u200d👩\u200d👧 "},
+		{s: "🇵🇹🇯🇵", width: 3, want: "🇵🇹"},
+		{s: "\x1b[31m한국어\x1b[0m", width: 4, want: "\x1b[31m한국\x1b[0m"},
+		{s: "\x1b[31m한국\x1b[0m어", width: 5, want: "\x1b[31m한국\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateANSI(tt.s, tt.width); got != tt.want {
+			t.Errorf("truncateANSI(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestBannerAlignsWideCharacters(t *testing.T) {
+	showBorder := true
+	info := &Info{Major: 1, Company: "北京科技有限公司", Author: "José\u0301 🚀", Repo: "👨\u200d👩\u200d👧\u200d👦 github.com/acme/家族"}
+
+	for _, opts := range []BannerOptions{
+		{AutoWidth: true, ShowBorder: &showBorder},
+		{FixedWidth: 25},
+		{FixedWidth: 26, Border: &BorderDouble},
+	} {
+		got := BannerWithOptions("株式会社 App", info, opts)
+		lines := strings.Split(got, "\n")
+		width := ansiWidth(lines[0])
+		for i, line := range lines {
+			if ansiWidth(line) != width {
+				t.Errorf("line %d width = %d, want %d:\n%s", i, ansiWidth(line), width, got)
+			}
+		}
+	}
+}
+
+func TestGetMaxLineWidthCountsColumns(t *testing.T) {
+	if got := getMaxLineWidth("██╗\n北京\nab"); got != 4 {
+		t.Errorf("getMaxLineWidth() = %d, want 4", got)
+	}
+}